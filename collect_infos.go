@@ -0,0 +1,17 @@
+package objwalker
+
+// WalkCollectInfos walk over v and return WalkInfo of every visited value in walk order.
+// Walker callback ignored.
+// WalkInfoPooling and SliceStreaming are disabled for the call, because collected WalkInfo
+// must stay valid after walk.
+func (w Walker) WalkCollectInfos(v interface{}) ([]*WalkInfo, error) {
+	var res []*WalkInfo
+	w.WalkInfoPooling = false
+	w.SliceStreaming = false
+	w.callback = func(info *WalkInfo) error {
+		res = append(res, info)
+		return nil
+	}
+	err := w.Walk(v)
+	return res, err
+}
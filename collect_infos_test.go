@@ -0,0 +1,77 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_WalkCollectInfos(t *testing.T) {
+	type Item struct {
+		ID   int
+		Tags []string
+	}
+	type Example struct {
+		Name  string
+		Items []Item
+		Ptr   *int
+	}
+
+	num := 5
+	v := Example{
+		Name:  "name",
+		Items: []Item{{ID: 1, Tags: []string{"a", "b"}}, {ID: 2}},
+		Ptr:   &num,
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		infos, err := New(nil).WalkCollectInfos(v)
+		require.NoError(t, err)
+
+		var paths []string
+		for _, info := range infos {
+			paths = append(paths, info.Path()+":"+info.Value.Kind().String())
+		}
+		require.Equal(t, []string{
+			":struct",
+			".Name:string",
+			".Items:slice",
+			".Items[0]:struct",
+			".Items[0].ID:int",
+			".Items[0].Tags:slice",
+			".Items[0].Tags[0]:string",
+			".Items[0].Tags[1]:string",
+			".Items[1]:struct",
+			".Items[1].ID:int",
+			".Items[1].Tags:slice",
+			".Ptr:ptr",
+			".Ptr:int",
+		}, paths)
+	})
+
+	t.Run("IgnoreCallback", func(t *testing.T) {
+		infos, err := New(func(info *WalkInfo) error {
+			return errTest
+		}).WalkCollectInfos(v)
+		require.NoError(t, err)
+		require.Len(t, infos, 13)
+	})
+
+	t.Run("PoolingAndStreamingDisabled", func(t *testing.T) {
+		infos, err := New(nil).WithWalkInfoPooling(true).WithSliceStreaming(true).WalkCollectInfos(v)
+		require.NoError(t, err)
+		require.Len(t, infos, 13)
+
+		require.Equal(t, ".Items[0].Tags[1]", infos[7].Path())
+		require.Equal(t, "b", infos[7].Value.Interface())
+		require.Equal(t, ".Items[1].ID", infos[9].Path())
+		require.Equal(t, 2, infos[9].Value.Interface())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		infos, err := New(nil).WithStrictKinds(reflect.Struct, reflect.String).WalkCollectInfos(v)
+		require.ErrorIs(t, err, ErrKindNotAllowed)
+		require.Len(t, infos, 2)
+	})
+}
@@ -0,0 +1,22 @@
+package objwalker
+
+import "reflect"
+
+// CollectType walk over v and return all values, which type assignable to T, in walk order.
+// Values of unexported fields read by DirectPointer if them are addressable,
+// other values, which can't be interfaced, skipped.
+func CollectType[T any](v interface{}) ([]T, error) {
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
+
+	var res []T
+	err := New(func(info *WalkInfo) error {
+		if !info.Value.Type().AssignableTo(targetType) || !info.Value.CanInterface() {
+			return nil
+		}
+		item := reflect.New(targetType).Elem()
+		item.Set(info.Value)
+		res = append(res, item.Interface().(T))
+		return nil
+	}).WithForceInterfaceable(true).Walk(v)
+	return res, err
+}
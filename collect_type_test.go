@@ -0,0 +1,48 @@
+package objwalker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectType(t *testing.T) {
+	type Node struct {
+		Name     string
+		Children []*Node
+	}
+	type Mixed struct {
+		Title string
+		Root  *Node
+		Extra map[string]interface{}
+		note  string
+	}
+
+	child := &Node{Name: "child"}
+	root := &Node{Name: "root", Children: []*Node{child}}
+	v := Mixed{Title: "title", Root: root, Extra: map[string]interface{}{"num": 1}, note: "hidden"}
+
+	t.Run("String", func(t *testing.T) {
+		res, err := CollectType[string](&v)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"title", "root", "child", "num", "hidden"}, res)
+	})
+
+	t.Run("Pointer", func(t *testing.T) {
+		res, err := CollectType[*Node](&v)
+		require.NoError(t, err)
+		require.Equal(t, []*Node{root, child}, res)
+	})
+
+	t.Run("Interface", func(t *testing.T) {
+		res, err := CollectType[interface{}](map[string]interface{}{"a": 1})
+		require.NoError(t, err)
+		require.Contains(t, res, interface{}(1))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		res, err := CollectType[int](nil)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+}
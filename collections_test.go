@@ -0,0 +1,212 @@
+package objwalker
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_ReverseSliceOrder(t *testing.T) {
+	for _, val := range []interface{}{[]int{10, 20, 30}, [3]int{10, 20, 30}} {
+		t.Run(reflect.TypeOf(val).String(), func(t *testing.T) {
+			var values []int64
+			var indexes []int
+			err := New(func(info *WalkInfo) error {
+				if info.Value.Kind() == reflect.Int {
+					values = append(values, info.Value.Int())
+					indexes = append(indexes, info.Index)
+				}
+				return nil
+			}).WithReverseSliceOrder(true).Walk(val)
+			require.NoError(t, err)
+			require.Equal(t, []int64{30, 20, 10}, values)
+			require.Equal(t, []int{2, 1, 0}, indexes)
+		})
+	}
+}
+
+func TestWalker_LeafBatch(t *testing.T) {
+	type S struct {
+		A int
+		B []string
+		C map[int]bool
+	}
+	val := S{A: 1, B: []string{"x", "y"}, C: map[int]bool{2: true}}
+
+	t.Run("Batches", func(t *testing.T) {
+		var composites []reflect.Kind
+		var batches [][]interface{}
+		err := New(func(info *WalkInfo) error {
+			composites = append(composites, info.Value.Kind())
+			return nil
+		}).WithLeafBatch(2, func(infos []*WalkInfo) error {
+			var batch []interface{}
+			for _, info := range infos {
+				batch = append(batch, info.Value.Interface())
+			}
+			batches = append(batches, batch)
+			return nil
+		}).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, []reflect.Kind{reflect.Struct, reflect.Slice, reflect.Map}, composites)
+		require.Equal(t, [][]interface{}{{1, "x"}, {"y", 2}, {true}}, batches)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		calls := 0
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithLeafBatch(10, func(infos []*WalkInfo) error {
+			calls++
+			return errTest
+		}).Walk(val)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestWalker_EmptyCollectionProbe(t *testing.T) {
+	type S struct {
+		Nil   []string
+		Empty []int
+		Arr   [0]bool
+		Map   map[string]float64
+		Full  []uint
+	}
+	val := S{Empty: []int{}, Full: []uint{1}}
+
+	walkProbes := func(w *Walker) []string {
+		return walkCollect(t, w, val, func(info *WalkInfo) (string, bool) {
+			if !info.IsProbe {
+				return "", false
+			}
+			require.True(t, info.Value.IsZero())
+			return info.Path() + ":" + info.Value.Kind().String(), true
+		})
+	}
+
+	require.Equal(t, []string{".Nil:string", ".Empty:int", ".Arr:bool", ".Map:string", ".Map:float64"},
+		walkProbes(New(nil).WithEmptyCollectionProbe(true)))
+	require.Empty(t, walkProbes(New(nil)))
+}
+
+func TestWalker_WalkStringRunes(t *testing.T) {
+	t.Run("Enabled", func(t *testing.T) {
+		var runes []rune
+		var indexes []int
+		stringCalled := false
+		err := New(func(info *WalkInfo) error {
+			if info.IsStringRune {
+				require.True(t, stringCalled)
+				require.Equal(t, reflect.Int32, info.Value.Kind())
+				require.Equal(t, 5, info.SiblingCount)
+				runes = append(runes, rune(info.Value.Int()))
+				indexes = append(indexes, info.Index)
+			} else {
+				require.Equal(t, "héllo", info.Value.String())
+				stringCalled = true
+			}
+			return nil
+		}).WithWalkStringRunes(true).Walk("héllo")
+		require.NoError(t, err)
+		require.Equal(t, []rune{'h', 'é', 'l', 'l', 'o'}, runes)
+		require.Equal(t, []int{0, 1, 2, 3, 4}, indexes)
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			cnt++
+			return ErrSkip
+		}).WithWalkStringRunes(true).Walk("héllo")
+		require.NoError(t, err)
+		require.Equal(t, 1, cnt)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			cnt++
+			return nil
+		}).Walk("héllo")
+		require.NoError(t, err)
+		require.Equal(t, 1, cnt)
+	})
+}
+
+func TestWalker_SliceStreaming(t *testing.T) {
+	t.Run("Visit", func(t *testing.T) {
+		val := make([]int, 1000)
+		for i := range val {
+			val[i] = i
+		}
+		sum := 0
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				require.Equal(t, info.Index, int(info.Value.Int()))
+				require.NotNil(t, info.Parent)
+				sum += int(info.Value.Int())
+			}
+			return nil
+		}).WithSliceStreaming(true).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, 999*1000/2, sum)
+	})
+
+	t.Run("LeafBatch", func(t *testing.T) {
+		var batched []int64
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithSliceStreaming(true).WithLeafBatch(2, func(infos []*WalkInfo) error {
+			for _, info := range infos {
+				batched = append(batched, info.Value.Int())
+			}
+			return nil
+		}).Walk([]int{1, 2, 3})
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2, 3}, batched)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		// walk with stored item WalkInfos and return channel, closed when backing array of slice released
+		walkAndStore := func(streaming bool) ([]*WalkInfo, chan struct{}) {
+			released := make(chan struct{})
+			arr := new([1000]int)
+			runtime.SetFinalizer(arr, func(*[1000]int) {
+				close(released)
+			})
+
+			var stored []*WalkInfo
+			require.NoError(t, New(func(info *WalkInfo) error {
+				if info.Parent != nil {
+					stored = append(stored, info)
+				}
+				return nil
+			}).WithSliceStreaming(streaming).Walk(arr[:]))
+			return stored, released
+		}
+		isReleased := func(released chan struct{}) bool {
+			for i := 0; i < 10; i++ {
+				runtime.GC()
+				select {
+				case <-released:
+					return true
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+			return false
+		}
+
+		stored, released := walkAndStore(true)
+		require.Len(t, stored, 1000)
+		require.True(t, isReleased(released))
+		runtime.KeepAlive(stored)
+
+		stored, released = walkAndStore(false)
+		require.False(t, isReleased(released))
+		runtime.KeepAlive(stored)
+	})
+}
@@ -0,0 +1,15 @@
+package objwalker
+
+// Contains walk over v and return true when pred return true for any walked value.
+// Walk stop on first matched value.
+func Contains(v interface{}, pred func(info *WalkInfo) bool) (bool, error) {
+	found := false
+	err := New(func(info *WalkInfo) error {
+		if pred(info) {
+			found = true
+			return ErrStop
+		}
+		return nil
+	}).Walk(v)
+	return found, err
+}
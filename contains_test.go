@@ -0,0 +1,47 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContains(t *testing.T) {
+	type S struct {
+		A    int
+		B    []string
+		Rest []int
+	}
+	val := S{A: 1, B: []string{"x", "needle"}, Rest: []int{1, 2, 3}}
+
+	t.Run("Found", func(t *testing.T) {
+		cnt := 0
+		res, err := Contains(val, func(info *WalkInfo) bool {
+			cnt++
+			return info.Value.Kind() == reflect.String && info.Value.String() == "needle"
+		})
+		require.NoError(t, err)
+		require.True(t, res)
+		require.Equal(t, 5, cnt)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		cnt := 0
+		res, err := Contains(val, func(info *WalkInfo) bool {
+			cnt++
+			return info.Value.Kind() == reflect.Float64
+		})
+		require.NoError(t, err)
+		require.False(t, res)
+		require.Equal(t, 9, cnt)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		res, err := Contains(nil, func(info *WalkInfo) bool {
+			return true
+		})
+		require.NoError(t, err)
+		require.False(t, res)
+	})
+}
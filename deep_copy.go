@@ -0,0 +1,186 @@
+package objwalker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var errCopyDestinationNotFound = errors.New("copy destination not found")
+
+// copier build deep copy of walked value
+type copier struct {
+	root        reflect.Value
+	dsts        map[*WalkInfo]reflect.Value
+	firstCopies map[addressTypeKey]reflect.Value
+	mapKeys     map[*WalkInfo]reflect.Value
+
+	// pending contains assignments, which can be done after fill copy of value only
+	// (set interfaces and map entries), it must be applied in reverse order
+	pending []func()
+
+	// links contains assignments of copies of visited values to other places of the copy,
+	// them applied after pending assignments, when copies are complete
+	links []func()
+}
+
+// DeepCopy return deep copy of v, built by walk over v: maps, slices, arrays, structs, pointers and
+// interfaces copied recursive.
+// It copy data, accessible by reflection only: unexported chans, funcs and unsafe pointers, which can't be
+// read without Value.Interface(), leaved zero in the copy.
+// Pointers to same value in source point to same value in copy.
+// v must not be changed concurrently with DeepCopy.
+func DeepCopy(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	c := &copier{
+		root:        reflect.New(reflect.TypeOf(v)).Elem(),
+		dsts:        make(map[*WalkInfo]reflect.Value),
+		firstCopies: make(map[addressTypeKey]reflect.Value),
+		mapKeys:     make(map[*WalkInfo]reflect.Value),
+		pending:     nil,
+		links:       nil,
+	}
+
+	err := New(c.visit).WithLoopProtection(false).WithForceInterfaceable(true).Walk(v)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(c.pending) - 1; i >= 0; i-- {
+		c.pending[i]()
+	}
+	for _, link := range c.links {
+		link()
+	}
+	return c.root.Interface(), nil
+}
+
+func (c *copier) visit(info *WalkInfo) error {
+	dst, err := c.destination(info)
+	if err != nil {
+		return err
+	}
+	c.dsts[info] = dst
+
+	key := addressTypeKey{ptr: info.DirectPointer, t: info.Value.Type()}
+	if info.IsVisited {
+		existed, ok := c.firstCopies[key]
+		if ok && info.Parent != nil && info.Parent.Value.Kind() == reflect.Pointer {
+			c.dsts[info.Parent].Set(existed.Addr())
+			return ErrSkip
+		}
+		if ok && isComposite(info.Value.Kind()) {
+			c.links = append(c.links, func() {
+				dst.Set(existed)
+			})
+			return ErrSkip
+		}
+	} else if info.HasDirectPointer() {
+		c.firstCopies[key] = dst
+	}
+
+	copyShallow(info.Value, dst)
+	return nil
+}
+
+// destination return place in copy for copy of info value
+func (c *copier) destination(info *WalkInfo) (reflect.Value, error) {
+	parent := info.Parent
+	if parent == nil {
+		return c.root, nil
+	}
+
+	parentDst, ok := c.dsts[parent]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("parent of %q: %w", info.Path(), errCopyDestinationNotFound)
+	}
+
+	switch {
+	case info.isMapKey:
+		dst := reflect.New(info.Value.Type()).Elem()
+		c.mapKeys[parent] = dst
+		return dst, nil
+	case info.isMapValue:
+		dst := reflect.New(info.Value.Type()).Elem()
+		key := c.mapKeys[parent]
+		c.pending = append(c.pending, func() {
+			parentDst.SetMapIndex(key, dst)
+		})
+		return dst, nil
+	}
+
+	//nolint:exhaustive
+	switch parent.Value.Kind() {
+	case reflect.Pointer:
+		return parentDst.Elem(), nil
+	case reflect.Interface:
+		dst := reflect.New(info.Value.Type()).Elem()
+		c.pending = append(c.pending, func() {
+			parentDst.Set(dst)
+		})
+		return dst, nil
+	case reflect.Struct:
+		field := parentDst.Field(info.SiblingIndex)
+		if !field.CanSet() {
+			field = reflect.NewAt(field.Type(), field.Addr().UnsafePointer()).Elem()
+		}
+		return field, nil
+	case reflect.Slice, reflect.Array:
+		return parentDst.Index(info.Index), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("child of %v at %q: %w", parent.Value.Kind(), info.Path(),
+			errCopyDestinationNotFound)
+	}
+}
+
+// copyShallow copy value without children to dst
+func copyShallow(v, dst reflect.Value) {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Interface, reflect.Struct, reflect.Array:
+		// filled by children
+	case reflect.Pointer:
+		if !v.IsNil() {
+			dst.Set(reflect.New(v.Type().Elem()))
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			dst.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Cap()))
+		}
+	default:
+		copySimple(v, dst)
+	}
+}
+
+// copySimple copy value of simple kind, it use kind specific getters for not interfaceable values
+func copySimple(v, dst reflect.Value) {
+	if v.CanInterface() {
+		dst.Set(v)
+		return
+	}
+
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Bool:
+		dst.SetBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dst.SetInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		dst.SetUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		dst.SetFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		dst.SetComplex(v.Complex())
+	case reflect.String:
+		dst.SetString(v.String())
+	default:
+		// chans, funcs and unsafe pointers can't be read without Value.Interface
+	}
+}
@@ -0,0 +1,110 @@
+package objwalker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepCopy(t *testing.T) {
+	type Item struct {
+		Name string
+		priv int
+	}
+	type S struct {
+		Int       int
+		Str       string
+		Slice     []Item
+		Map       map[string]*Item
+		Interface interface{}
+		Array     [2]int
+		Ptr       *Item
+		NilPtr    *Item
+		NilSlice  []int
+		NilMap    map[int]int
+		private   Item
+	}
+
+	val := &S{
+		Int:       1,
+		Str:       "str",
+		Slice:     []Item{{Name: "a", priv: 1}, {Name: "b", priv: 2}},
+		Map:       map[string]*Item{"c": {Name: "c", priv: 3}},
+		Interface: Item{Name: "d", priv: 4},
+		Array:     [2]int{5, 6},
+		Ptr:       &Item{Name: "e", priv: 7},
+		private:   Item{Name: "f", priv: 8},
+	}
+
+	res, err := DeepCopy(val)
+	require.NoError(t, err)
+	copied := res.(*S)
+	require.Equal(t, val, copied)
+
+	require.NotSame(t, val, copied)
+	require.NotSame(t, &val.Slice[0], &copied.Slice[0])
+	require.NotSame(t, val.Map["c"], copied.Map["c"])
+	require.NotSame(t, val.Ptr, copied.Ptr)
+
+	t.Run("ByValue", func(t *testing.T) {
+		res, err := DeepCopy(*val)
+		require.NoError(t, err)
+		require.Equal(t, *val, res)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		res, err := DeepCopy(nil)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("SharedPointers", func(t *testing.T) {
+		type Pair struct {
+			A *Item
+			B *Item
+			C []*Item
+		}
+		item := &Item{Name: "shared"}
+		pair := Pair{A: item, B: item, C: []*Item{item}}
+
+		res, err := DeepCopy(pair)
+		require.NoError(t, err)
+		copiedPair := res.(Pair)
+		require.Equal(t, pair, copiedPair)
+		require.NotSame(t, item, copiedPair.A)
+		require.Same(t, copiedPair.A, copiedPair.B)
+		require.Same(t, copiedPair.A, copiedPair.C[0])
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+		}
+		node := &Node{}
+		node.Next = node
+
+		res, err := DeepCopy(node)
+		require.NoError(t, err)
+		copiedNode := res.(*Node)
+		require.NotSame(t, node, copiedNode)
+		require.Same(t, copiedNode, copiedNode.Next)
+	})
+
+	t.Run("CycleInSlice", func(t *testing.T) {
+		s := []interface{}{nil, 1}
+		s[0] = s
+
+		res, err := DeepCopy(s)
+		require.NoError(t, err)
+		copied := res.([]interface{})
+		require.Equal(t, 1, copied[1])
+		inner := copied[0].([]interface{})
+		require.Equal(t, 1, inner[1])
+		require.Equal(t, 1, inner[0].([]interface{})[1])
+
+		// cycle is kept in copy
+		inner[1] = 2
+		require.Equal(t, 2, inner[0].([]interface{})[1])
+		require.Equal(t, 1, s[1])
+	})
+}
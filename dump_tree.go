@@ -0,0 +1,88 @@
+package objwalker
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type dumpNode struct {
+	ID       int         `json:"id"`
+	Type     string      `json:"type"`
+	Kind     string      `json:"kind"`
+	Field    string      `json:"field,omitempty"`
+	MapKey   bool        `json:"mapKey,omitempty"`
+	MapValue bool        `json:"mapValue,omitempty"`
+	Value    string      `json:"value,omitempty"`
+	Ref      int         `json:"$ref,omitempty"`
+	Cycle    bool        `json:"cycle,omitempty"`
+	Children []*dumpNode `json:"children,omitempty"`
+}
+
+// DumpTree walk over v and return json representation of walk tree for debug purposes.
+// Every node has id, type and kind, leaf nodes has string representation of value.
+// Repeated visit of value (by loop protection rules) represented as node with $ref field, contains id of
+// first visit node. If repeated value closes cycle - node has cycle flag and $ref to the ancestor.
+// Cycles through unaddressable values detected by content hash, see Walker.ValueHashLoopProtection.
+func DumpTree(v interface{}) (string, error) {
+	var root *dumpNode
+	nodes := make(map[*WalkInfo]*dumpNode)
+	firstVisits := make(map[addressTypeKey]int)
+
+	addNode := func(info *WalkInfo) *dumpNode {
+		node := &dumpNode{
+			ID:       len(nodes) + 1,
+			Type:     info.Value.Type().String(),
+			Kind:     info.Value.Kind().String(),
+			Field:    "",
+			MapKey:   info.IsMapKey(),
+			MapValue: info.IsMapValue(),
+			Value:    "",
+			Ref:      0,
+			Cycle:    false,
+			Children: nil,
+		}
+		nodes[info] = node
+		if info.StructField != nil {
+			node.Field = info.StructField.Name
+		}
+
+		if info.Parent == nil {
+			root = node
+		} else {
+			parent := nodes[info.Parent]
+			parent.Children = append(parent.Children, node)
+		}
+
+		if !isComposite(info.Value.Kind()) {
+			node.Value = fmt.Sprint(info.Value)
+		}
+		return node
+	}
+
+	err := New(func(info *WalkInfo) error {
+		node := addNode(info)
+		if info.HasDirectPointer() {
+			firstVisits[addressTypeKey{ptr: info.DirectPointer, t: info.Value.Type()}] = node.ID
+		}
+		return nil
+	}).WithValueHashLoopProtection(true).WithOnLoopSkip(func(info *WalkInfo) {
+		node := addNode(info)
+		if ancestor, ok := nodes[info.ClosesCycleWith]; ok {
+			node.Cycle = true
+			node.Ref = ancestor.ID
+			return
+		}
+		if info.HasDirectPointer() {
+			node.Ref = firstVisits[addressTypeKey{ptr: info.DirectPointer, t: info.Value.Type()}]
+		}
+	}).Walk(v)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
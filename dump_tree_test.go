@@ -0,0 +1,109 @@
+package objwalker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpTree(t *testing.T) {
+	t.Run("Kinds", func(t *testing.T) {
+		type S struct {
+			Int   int
+			Slice []string
+			Map   map[string]bool
+		}
+		res, err := DumpTree(S{Int: 1, Slice: []string{"a"}, Map: map[string]bool{"k": true}})
+		require.NoError(t, err)
+
+		var root dumpNode
+		require.NoError(t, json.Unmarshal([]byte(res), &root))
+		require.Equal(t, "struct", root.Kind)
+		require.Len(t, root.Children, 3)
+
+		require.Equal(t, "Int", root.Children[0].Field)
+		require.Equal(t, "int", root.Children[0].Kind)
+		require.Equal(t, "1", root.Children[0].Value)
+
+		require.Equal(t, "slice", root.Children[1].Kind)
+		require.Equal(t, "[]string", root.Children[1].Type)
+		require.Equal(t, "a", root.Children[1].Children[0].Value)
+
+		require.Equal(t, "map", root.Children[2].Kind)
+		require.True(t, root.Children[2].Children[0].MapKey)
+		require.Equal(t, "k", root.Children[2].Children[0].Value)
+		require.True(t, root.Children[2].Children[1].MapValue)
+		require.Equal(t, "true", root.Children[2].Children[1].Value)
+	})
+
+	t.Run("Ref", func(t *testing.T) {
+		type S struct {
+			P *S
+		}
+		s := S{}
+		s.P = &s
+
+		res, err := DumpTree(&s)
+		require.NoError(t, err)
+		require.Contains(t, res, `"$ref": 2`)
+
+		var root dumpNode
+		require.NoError(t, json.Unmarshal([]byte(res), &root))
+		structNode := root.Children[0]
+		require.Equal(t, 2, structNode.ID)
+		refNode := structNode.Children[0].Children[0]
+		require.Equal(t, structNode.ID, refNode.Ref)
+		require.True(t, refNode.Cycle)
+		require.Empty(t, refNode.Children)
+	})
+
+	t.Run("SharedRef", func(t *testing.T) {
+		type S struct {
+			A *int
+			B *int
+		}
+		x := 1
+		res, err := DumpTree(&S{A: &x, B: &x})
+		require.NoError(t, err)
+
+		var root dumpNode
+		require.NoError(t, json.Unmarshal([]byte(res), &root))
+		fields := root.Children[0].Children
+		first := fields[0].Children[0]
+		require.Equal(t, "1", first.Value)
+		refNode := fields[1].Children[0]
+		require.Equal(t, first.ID, refNode.Ref)
+		require.False(t, refNode.Cycle)
+	})
+
+	t.Run("UnaddressableCycle", func(t *testing.T) {
+		m := map[string]interface{}{}
+		m["self"] = m
+
+		res, err := DumpTree(m)
+		require.NoError(t, err)
+
+		var root dumpNode
+		require.NoError(t, json.Unmarshal([]byte(res), &root))
+		require.Len(t, root.Children, 2)
+		valueNode := root.Children[1]
+		require.True(t, valueNode.MapValue)
+		cycleNode := valueNode.Children[0]
+		require.Equal(t, "map", cycleNode.Kind)
+		require.True(t, cycleNode.Cycle)
+		require.Equal(t, root.ID, cycleNode.Ref)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		res, err := DumpTree(nil)
+		require.NoError(t, err)
+		require.Equal(t, "null", res)
+	})
+
+	t.Run("Chan", func(t *testing.T) {
+		res, err := DumpTree(make(chan int))
+		require.NoError(t, err)
+		require.Contains(t, res, `"kind": "chan"`)
+	})
+}
@@ -0,0 +1,202 @@
+package objwalker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_CollectErrors(t *testing.T) {
+	type Inner struct {
+		Val int
+	}
+	type S struct {
+		A     int
+		B     string
+		C     int
+		Inner Inner
+	}
+
+	errA := errors.New("a")
+	errC := errors.New("c")
+	errInner := errors.New("inner")
+
+	wasInnerVal := false
+	wasB := false
+	err := New(func(info *WalkInfo) error {
+		switch info.Value.Interface() {
+		case 1:
+			return errA
+		case 3:
+			return errC
+		case "2":
+			wasB = true
+		case Inner{Val: 4}:
+			return errInner
+		case 4:
+			wasInnerVal = true
+		}
+		return nil
+	}).WithCollectErrors(true).Walk(S{A: 1, B: "2", C: 3, Inner: Inner{Val: 4}})
+
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errC)
+	require.ErrorIs(t, err, errInner)
+	require.True(t, wasB)
+	require.False(t, wasInnerVal)
+
+	t.Run("NoErrors", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			return nil
+		}).WithCollectErrors(true).Walk(S{}))
+	})
+
+	t.Run("WithWalkError", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				return errA
+			}
+			return nil
+		}).WithCollectErrors(true).WithMaxBytes(int(unsafe.Sizeof(int(0)))).Walk(struct{ A, B int }{})
+		require.ErrorIs(t, err, errA)
+		require.ErrorIs(t, err, ErrByteBudgetExceeded)
+	})
+}
+
+func TestWalker_ErrStop(t *testing.T) {
+	var visited []interface{}
+	err := New(func(info *WalkInfo) error {
+		visited = append(visited, info.Value.Interface())
+		if info.Value.Kind() == reflect.Int && info.Value.Int() == 2 {
+			return ErrStop
+		}
+		return nil
+	}).WithCollectErrors(true).Walk([]int{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{[]int{1, 2, 3}, 1, 2}, visited)
+}
+
+func TestWalker_RecoverPanics(t *testing.T) {
+	type S struct {
+		A int
+		B int
+	}
+
+	callback := func(info *WalkInfo) error {
+		if info.StructField != nil && info.StructField.Name == "A" {
+			panic("test panic")
+		}
+		if info.StructField != nil && info.StructField.Name == "B" {
+			panic(errTest)
+		}
+		return nil
+	}
+
+	t.Run("Stop", func(t *testing.T) {
+		err := New(callback).WithRecoverPanics(true).Walk(S{})
+		require.ErrorIs(t, err, ErrCallbackPanic)
+		require.Contains(t, err.Error(), `".A"`)
+		require.Contains(t, err.Error(), "test panic")
+	})
+
+	t.Run("Collect", func(t *testing.T) {
+		err := New(callback).WithRecoverPanics(true).WithCollectErrors(true).Walk(S{})
+		require.ErrorIs(t, err, ErrCallbackPanic)
+		require.ErrorIs(t, err, errTest)
+		require.Contains(t, err.Error(), `".A"`)
+		require.Contains(t, err.Error(), `".B"`)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		require.Panics(t, func() {
+			_ = New(callback).Walk(S{})
+		})
+	})
+}
+
+func TestWalker_OnError(t *testing.T) {
+	type S struct {
+		Items []int
+	}
+	val := S{Items: []int{1, 2, 3}}
+
+	callback := func(info *WalkInfo) error {
+		if info.Value.Kind() == reflect.Int && info.Value.Int() >= 2 {
+			return errTest
+		}
+		return nil
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		var paths []string
+		err := New(callback).WithOnError(func(info *WalkInfo, err error) {
+			require.ErrorIs(t, err, errTest)
+			paths = append(paths, info.Path())
+		}).Walk(val)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, []string{".Items[1]"}, paths)
+	})
+
+	t.Run("CollectErrors", func(t *testing.T) {
+		var paths []string
+		err := New(callback).WithOnError(func(info *WalkInfo, err error) {
+			paths = append(paths, info.Path())
+		}).WithCollectErrors(true).Walk(val)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, []string{".Items[1]", ".Items[2]"}, paths)
+	})
+
+	t.Run("SkipAndStop", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Slice {
+				return ErrSkip
+			}
+			if info.Value.Kind() == reflect.Struct {
+				return nil
+			}
+			return ErrStop
+		}).WithOnError(func(info *WalkInfo, err error) {
+			require.Fail(t, "unexpected error", err)
+		}).Walk(struct {
+			A []int
+			B int
+		}{})
+		require.NoError(t, err)
+	})
+}
+
+func TestWalker_MaxCallbackErrors(t *testing.T) {
+	val := []int{1, 2, 3, 4, 5}
+	walk := func(maxErrors int) []error {
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				return fmt.Errorf("item %v: %w", info.Value.Int(), errTest)
+			}
+			return nil
+		}).WithCollectErrors(true).WithMaxCallbackErrors(maxErrors).Walk(val)
+		require.Error(t, err)
+		return err.(interface{ Unwrap() []error }).Unwrap()
+	}
+
+	t.Run("Truncated", func(t *testing.T) {
+		errs := walk(2)
+		require.Len(t, errs, 3)
+		require.EqualError(t, errs[0], "item 1: test")
+		require.EqualError(t, errs[1], "item 2: test")
+		require.Equal(t, ErrCallbackErrorsTruncated, errs[2])
+	})
+
+	t.Run("Exact", func(t *testing.T) {
+		errs := walk(5)
+		require.Len(t, errs, 5)
+		require.NotContains(t, errs, ErrCallbackErrorsTruncated)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		require.Len(t, walk(0), 5)
+	})
+}
@@ -0,0 +1,81 @@
+package objwalker
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_WalkFuncSignature(t *testing.T) {
+	type S struct {
+		F   func(int) string
+		Nil func(a, b bool, c ...float64) (bool, error)
+	}
+	val := S{F: strconv.Itoa}
+
+	walkNodes := func(w *Walker) []string {
+		return walkCollect(t, w, val, func(info *WalkInfo) (string, bool) {
+			switch {
+			case info.IsFuncParam:
+				return fmt.Sprintf("param%v:%v", info.SiblingIndex, info.Value.Kind()), true
+			case info.IsFuncResult:
+				return fmt.Sprintf("result%v:%v", info.SiblingIndex, info.Value.Kind()), true
+			default:
+				return info.Value.Kind().String(), true
+			}
+		})
+	}
+
+	require.Equal(t, []string{
+		"struct",
+		"func", "param0:int", "result0:string",
+		"func", "param0:bool", "param1:bool", "param2:slice", "result0:bool", "result1:interface",
+	}, walkNodes(New(nil).WithWalkFuncSignature(true)))
+	require.Equal(t, []string{"struct", "func", "func"}, walkNodes(New(nil)))
+}
+
+func TestWalker_CanonicalizeFunc(t *testing.T) {
+	type Point struct {
+		X, Y int
+	}
+	type S struct {
+		A *Point
+		B *Point
+		C *Point
+	}
+	val := S{A: &Point{X: 1, Y: 2}, B: &Point{X: 1, Y: 2}, C: &Point{X: 3}}
+
+	contentKey := func(info *WalkInfo) (interface{}, bool) {
+		if info.Value.Type() != reflect.TypeOf(Point{}) {
+			return nil, false
+		}
+		return info.Value.Interface(), true
+	}
+
+	t.Run("LoopProtection", func(t *testing.T) {
+		var points []string
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Type() == reflect.TypeOf(Point{}) {
+				points = append(points, info.Path())
+			}
+			return nil
+		}).WithCanonicalizeFunc(contentKey).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, []string{".A", ".C"}, points)
+	})
+
+	t.Run("WithoutLoopProtection", func(t *testing.T) {
+		visited := map[string]bool{}
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Type() == reflect.TypeOf(Point{}) {
+				visited[info.Path()] = info.IsVisited
+			}
+			return nil
+		}).WithCanonicalizeFunc(contentKey).WithLoopProtection(false).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{".A": false, ".B": true, ".C": false}, visited)
+	})
+}
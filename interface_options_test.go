@@ -0,0 +1,86 @@
+package objwalker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_InterfaceResolver(t *testing.T) {
+	type Original struct {
+		Val int
+	}
+	type Resolved struct {
+		Name string
+	}
+	val := []interface{}{Original{Val: 1}, 2, nil}
+
+	var visited []interface{}
+	resolverCalls := 0
+	require.NoError(t, New(func(info *WalkInfo) error {
+		if info.Parent != nil && info.Parent.Value.Kind() == reflect.Interface {
+			visited = append(visited, info.Value.Interface())
+		}
+		return nil
+	}).WithInterfaceResolver(func(info *WalkInfo) (reflect.Value, bool) {
+		resolverCalls++
+		switch elem := info.Value.Elem().Interface().(type) {
+		case Original:
+			return reflect.ValueOf(Resolved{Name: fmt.Sprint(elem.Val)}), true
+		case int:
+			return reflect.Value{}, true
+		default:
+			return reflect.Value{}, false
+		}
+	}).Walk(val))
+	require.Equal(t, 2, resolverCalls)
+	require.Equal(t, []interface{}{Resolved{Name: "1"}}, visited)
+}
+
+func TestWalker_InterfaceTypeCallback(t *testing.T) {
+	type Inner struct {
+		A int
+	}
+	type S struct {
+		I   interface{}
+		Nil interface{}
+		Str interface{}
+	}
+	val := S{I: Inner{A: 1}, Str: "s"}
+
+	t.Run("Order", func(t *testing.T) {
+		var events []string
+		err := New(func(info *WalkInfo) error {
+			events = append(events, "callback:"+info.Value.Kind().String())
+			return nil
+		}).WithInterfaceTypeCallback(func(info *WalkInfo, typ reflect.Type) error {
+			require.Equal(t, reflect.Interface, info.Value.Kind())
+			events = append(events, "type:"+typ.String())
+			return nil
+		}).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"callback:struct",
+			"callback:interface", "type:objwalker.Inner", "callback:struct", "callback:int",
+			"callback:interface",
+			"callback:interface", "type:string", "callback:string",
+		}, events)
+	})
+
+	t.Run("SkipAndError", func(t *testing.T) {
+		var kinds []reflect.Kind
+		err := New(func(info *WalkInfo) error {
+			kinds = append(kinds, info.Value.Kind())
+			return nil
+		}).WithInterfaceTypeCallback(func(info *WalkInfo, t reflect.Type) error {
+			if t.Kind() == reflect.Struct {
+				return ErrSkip
+			}
+			return errTest
+		}).Walk(val)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, []reflect.Kind{reflect.Struct, reflect.Interface, reflect.Interface, reflect.Interface}, kinds)
+	})
+}
@@ -0,0 +1,84 @@
+package objwalker
+
+import (
+	"errors"
+	"sync"
+)
+
+var errIteratorStopped = errors.New("iterator stopped")
+
+// Iterator run walk over v in separate goroutine and provide pull-style access to walked values.
+// The walker callback ignored, WalkInfoPooling disabled: received infos are valid after next calls.
+//
+// next return values in same order as callback receive it, ok is false after walk finished.
+// Walk goroutine paused while caller handle received value (until next call or stop), so it is safe
+// to manipulate with the value between next calls.
+//
+// stop must be called for free walk goroutine (it is safe to call it many times), it return walk error if any.
+// Usually it is good idea to defer stop() just after create iterator.
+func (w Walker) Iterator(v interface{}) (next func() (info *WalkInfo, ok bool), stop func() error) {
+	requests := make(chan empty)
+	items := make(chan *WalkInfo)
+	cancel := make(chan empty)
+	finished := make(chan empty)
+
+	var walkErr error
+
+	// requested is true if request for next item received, but doesn't answered yet,
+	// it is used by walk goroutine only
+	requested := false
+	w.WalkInfoPooling = false
+	w.callback = func(info *WalkInfo) error {
+		if !requested {
+			select {
+			case <-requests:
+			case <-cancel:
+				return errIteratorStopped
+			}
+		}
+		items <- info
+
+		// wait next request before continue walk: caller can handle info without race with walk
+		select {
+		case <-requests:
+			requested = true
+			return nil
+		case <-cancel:
+			return errIteratorStopped
+		}
+	}
+
+	go func() {
+		defer close(finished)
+		walkErr = w.Walk(v)
+	}()
+
+	next = func() (*WalkInfo, bool) {
+		select {
+		case requests <- empty{}:
+		case <-finished:
+			return nil, false
+		}
+
+		select {
+		case info := <-items:
+			return info, true
+		case <-finished:
+			return nil, false
+		}
+	}
+
+	var stopOnce sync.Once
+	stop = func() error {
+		stopOnce.Do(func() {
+			close(cancel)
+		})
+		<-finished
+		if errors.Is(walkErr, errIteratorStopped) {
+			return nil
+		}
+		return walkErr
+	}
+
+	return next, stop
+}
@@ -0,0 +1,95 @@
+package objwalker
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_Iterator(t *testing.T) {
+	type S struct {
+		A int
+		B string
+		C []int
+	}
+	val := S{A: 1, B: "2", C: []int{3, 4}}
+
+	var expected []interface{}
+	require.NoError(t, New(func(info *WalkInfo) error {
+		expected = append(expected, info.Value.Interface())
+		return nil
+	}).Walk(val))
+
+	t.Run("Full", func(t *testing.T) {
+		next, stop := New(nil).Iterator(val)
+		defer func() {
+			_ = stop()
+		}()
+
+		var actual []interface{}
+		for info, ok := next(); ok; info, ok = next() {
+			actual = append(actual, info.Value.Interface())
+		}
+		require.Equal(t, expected, actual)
+		require.NoError(t, stop())
+
+		info, ok := next()
+		require.False(t, ok)
+		require.Nil(t, info)
+	})
+
+	t.Run("EarlyStop", func(t *testing.T) {
+		goroutines := runtime.NumGoroutine()
+
+		next, stop := New(nil).Iterator(val)
+		info, ok := next()
+		require.True(t, ok)
+		require.Equal(t, expected[0], info.Value.Interface())
+		require.NoError(t, stop())
+		require.NoError(t, stop())
+
+		_, ok = next()
+		require.False(t, ok)
+
+		// walk goroutine may be not fully exited just after stop return
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		require.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+	})
+
+	t.Run("ModifyBetweenNext", func(t *testing.T) {
+		v := &S{A: 1, B: "2", C: []int{3, 4}}
+		next, stop := New(nil).WithWalkInfoPooling(true).Iterator(v)
+		defer func() {
+			_ = stop()
+		}()
+
+		var paths []string
+		for info, ok := next(); ok; info, ok = next() {
+			paths = append(paths, info.Path())
+			switch info.Value.Kind() {
+			case reflect.Int:
+				require.NoError(t, info.SetInt(info.Value.Int()*10))
+			case reflect.Slice:
+				// walk continue with new slice
+				require.NoError(t, info.TrySet(reflect.ValueOf([]int{5})))
+			default:
+			}
+		}
+		require.NoError(t, stop())
+		require.Equal(t, []string{"", "", ".A", ".B", ".C", ".C[0]"}, paths)
+		require.Equal(t, &S{A: 10, B: "2", C: []int{50}}, v)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		next, stop := New(nil).WithMaxBytes(1).Iterator(val.C)
+		_, ok := next()
+		require.False(t, ok)
+		require.ErrorIs(t, stop(), ErrByteBudgetExceeded)
+	})
+}
@@ -0,0 +1,185 @@
+package objwalker
+
+import "reflect"
+
+// keepBranches is result of look ahead for Walker.KeepPredicate: values of walked object, identified by position
+// from parent value or by address, and flags of matches in subtrees of them
+type keepBranches struct {
+	// matched[id] is true if value with the id or any of its descendants matched by predicate, id 0 is unknown value
+	matched []bool
+	// parents[id] is id of parent value, 0 for root of look ahead
+	parents   []int
+	positions map[keepPosition]int
+	addresses map[addressTypeKey]int
+}
+
+// keepPosition identify value by parent value and position in it
+type keepPosition struct {
+	parent       int
+	role         Role
+	segment      string
+	siblingIndex int
+	funcResult   bool
+	t            reflect.Type
+}
+
+func newKeepBranches() *keepBranches {
+	return &keepBranches{
+		matched:   []bool{false},
+		parents:   []int{0},
+		positions: make(map[keepPosition]int),
+		addresses: make(map[addressTypeKey]int),
+	}
+}
+
+// keepPositionOf return position of info and false for roots and values, which parent has no id
+func keepPositionOf(info *WalkInfo) (keepPosition, bool) {
+	if info.Parent == nil || info.Parent.keepID == 0 {
+		return keepPosition{}, false
+	}
+	return keepPosition{
+		parent:       info.Parent.keepID,
+		role:         info.Role(),
+		segment:      info.pathSegment(),
+		siblingIndex: info.SiblingIndex,
+		funcResult:   info.IsFuncResult,
+		t:            info.Value.Type(),
+	}, true
+}
+
+// find return id of value, registered before
+func (kb *keepBranches) find(info *WalkInfo) (int, bool) {
+	if position, ok := keepPositionOf(info); ok {
+		if id, ok := kb.positions[position]; ok {
+			return id, true
+		}
+	}
+	if info.DirectPointer != zeroPointer {
+		id, ok := kb.addresses[addressTypeKey{ptr: info.DirectPointer, t: info.Value.Type()}]
+		return id, ok
+	}
+	return 0, false
+}
+
+// register return id for info. Values with same position (for example map keys with same text) share id.
+func (kb *keepBranches) register(info *WalkInfo) int {
+	position, hasPosition := keepPositionOf(info)
+	if hasPosition {
+		if id, ok := kb.positions[position]; ok {
+			return id
+		}
+	}
+
+	id := len(kb.matched)
+	kb.matched = append(kb.matched, false)
+	parent := 0
+	if info.Parent != nil {
+		parent = info.Parent.keepID
+	}
+	kb.parents = append(kb.parents, parent)
+	if hasPosition {
+		kb.positions[position] = id
+	}
+	if info.DirectPointer != zeroPointer {
+		key := addressTypeKey{ptr: info.DirectPointer, t: info.Value.Type()}
+		if _, ok := kb.addresses[key]; !ok {
+			kb.addresses[key] = id
+		}
+	}
+	return id
+}
+
+// isKeptBranch return true if Walker.KeepPredicate match info or any of its descendants
+func (state *walkerState) isKeptBranch(info *WalkInfo) (bool, error) {
+	if state.KeepPredicate(info) {
+		info.keepMatched = true
+		return true, nil
+	}
+
+	if state.keepBranches == nil {
+		state.keepBranches = newKeepBranches()
+	}
+	id, ok := state.keepBranches.find(info)
+	if !ok {
+		var err error
+		if id, err = state.lookAhead(info); err != nil {
+			return false, err
+		}
+	}
+	info.keepID = id
+	return state.keepBranches.matched[id], nil
+}
+
+// lookAhead walk over subtree of info once, register all values of the subtree with flags of matches
+// in subtrees of them and return id of info
+func (state *walkerState) lookAhead(info *WalkInfo) (int, error) {
+	kb := state.keepBranches
+	firstID := len(kb.matched)
+	rootID := 0
+
+	lookAhead := lookAheadOptions(state.Walker)
+	probe := *info
+	lookAhead.callback = func(descendant *WalkInfo) error {
+		isProbe := descendant == &probe
+		if !isProbe && state.isVisitedBefore(descendant) {
+			// main walk skip the value
+			return ErrSkip
+		}
+		descendant.keepID = kb.register(descendant)
+		if isProbe {
+			rootID = descendant.keepID
+			return nil
+		}
+		if state.KeepPredicate(descendant) {
+			// main walk keep whole subtree of the value, without look ahead
+			kb.matched[descendant.keepID] = true
+			return ErrSkip
+		}
+		return nil
+	}
+	if err := newWalkerState(lookAhead).walkValue(&probe); err != nil {
+		return 0, err
+	}
+
+	// children registered after parents, so reverse order of ids is post-order of subtree
+	for id := len(kb.matched) - 1; id > firstID; id-- {
+		if parent := kb.parents[id]; kb.matched[id] && parent >= firstID {
+			kb.matched[parent] = true
+		}
+	}
+	return rootID, nil
+}
+
+// isVisitedBefore return true if loop protection of state mark same value as visited already
+func (state *walkerState) isVisitedBefore(info *WalkInfo) bool {
+	if state.stats.LoopProtectionDisabled || !state.LoopProtection || info.DirectPointer == zeroPointer {
+		return false
+	}
+	_, visited := state.visited[info.DirectPointer][info.Value.Type()]
+	return visited
+}
+
+// lookAheadOptions return copy of walker options without hooks, limits and options, which change values,
+// it keep options, which affect structure of walked values only
+func lookAheadOptions(opts Walker) Walker {
+	opts.KeepPredicate = nil
+	opts.MaxBytes = 0
+	opts.NamedTypeHook = nil
+	opts.CollectErrors = false
+	opts.SkipRoot = false
+	opts.Profiling = false
+	opts.StopOnType = nil
+	opts.OncePerType = false
+	opts.LeafBatchFunc = nil
+	opts.OnError = nil
+	opts.InterfaceTypeCallback = nil
+	opts.WalkInfoPooling = false
+	opts.OnLoopSkip = nil
+	opts.SliceStreaming = false
+	opts.StringInterning = false
+
+	// look ahead must not change walked values
+	opts.FieldRewriter = nil
+	opts.ReadOnly = true
+	return opts
+}
@@ -0,0 +1,133 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_KeepPredicate(t *testing.T) {
+	type WithString struct {
+		X int
+		S string
+	}
+	type WithoutString struct {
+		Y int
+	}
+	type S struct {
+		A WithString
+		B WithoutString
+		C []int
+		D []string
+		M map[int]string
+	}
+	val := S{
+		A: WithString{X: 1, S: "s"},
+		B: WithoutString{Y: 2},
+		C: []int{3},
+		D: []string{"d"},
+		M: map[int]string{4: "m"},
+	}
+
+	t.Run("ContainsString", func(t *testing.T) {
+		paths := walkPaths(t, New(nil).WithKeepPredicate(func(info *WalkInfo) bool {
+			return info.Value.Kind() == reflect.String
+		}), val)
+		require.Equal(t, []string{"", ".A", ".A.S", ".D", ".D[0]", ".M", ".M[4]"}, paths)
+	})
+
+	t.Run("KeepSubtree", func(t *testing.T) {
+		paths := walkPaths(t, New(nil).WithKeepPredicate(func(info *WalkInfo) bool {
+			return info.Value.Type() == reflect.TypeOf(WithoutString{})
+		}), val)
+		require.Equal(t, []string{"", ".B", ".B.Y"}, paths)
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		paths := walkPaths(t, New(nil).WithKeepPredicate(func(info *WalkInfo) bool {
+			return false
+		}), val)
+		require.Empty(t, paths)
+	})
+
+	t.Run("FieldRewriter", func(t *testing.T) {
+		type Pair struct {
+			A string
+			B string
+		}
+		val := &Pair{A: "a", B: "b"}
+		rewrites := 0
+		paths := walkPaths(t, New(nil).WithKeepPredicate(func(info *WalkInfo) bool {
+			return info.Value.Kind() == reflect.String
+		}).WithFieldRewriter(func(info *WalkInfo) (reflect.Value, bool) {
+			rewrites++
+			return reflect.ValueOf(info.Value.String() + "!"), true
+		}), val)
+		require.Equal(t, 2, rewrites)
+		require.Equal(t, &Pair{A: "a!", B: "b!"}, val)
+		require.Equal(t, []string{"", "", ".A", ".B"}, paths)
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+			Name string
+		}
+		node := &Node{Name: "a"}
+		node.Next = node
+		paths := walkPaths(t, New(nil).WithKeepPredicate(func(info *WalkInfo) bool {
+			return info.Value.Kind() == reflect.String
+		}), node)
+		require.Equal(t, []string{"", "", ".Name"}, paths)
+	})
+
+	t.Run("DeepLinear", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+			Val  int
+		}
+		var root *Node
+		for i := 0; i < 100; i++ {
+			root = &Node{Next: root, Val: i}
+		}
+		nodes := len(walkPaths(t, New(nil), root))
+
+		calls := 0
+		paths := walkPaths(t, New(nil).WithKeepPredicate(func(info *WalkInfo) bool {
+			calls++
+			return info.Value.Kind() == reflect.Int && info.Value.Int() == 0
+		}), root)
+		require.Len(t, paths, 2*100+1)
+		require.LessOrEqual(t, calls, 2*nodes)
+	})
+
+	t.Run("SameKeyText", func(t *testing.T) {
+		val := map[interface{}][]string{1: nil, "1": {"s"}}
+		paths := walkPaths(t, New(nil).WithKeepPredicate(func(info *WalkInfo) bool {
+			return info.Value.Kind() == reflect.String && info.Value.String() == "s"
+		}), val)
+		require.Contains(t, paths, "[1][0]")
+	})
+
+	t.Run("SkipRoot", func(t *testing.T) {
+		paths := walkPaths(t, New(nil).WithSkipRoot(true).WithKeepPredicate(func(info *WalkInfo) bool {
+			return info.Value.Kind() == reflect.String
+		}), &val)
+		require.Equal(t, []string{".A", ".A.S", ".D", ".D[0]", ".M", ".M[4]"}, paths)
+	})
+
+	t.Run("WalkAll", func(t *testing.T) {
+		inner := &WithString{S: "s"}
+		var paths []string
+		err := New(func(info *WalkInfo) error {
+			paths = append(paths, info.Path())
+			return nil
+		}).WithKeepPredicate(func(info *WalkInfo) bool {
+			return info.Value.Kind() == reflect.String
+		}).WalkAll([]*WithString{inner}, []*WithString{inner})
+		require.NoError(t, err)
+		// inner is visited from first root only, second root has no matches
+		require.Equal(t, []string{"", "[0]", "[0]", "[0].S"}, paths)
+	})
+}
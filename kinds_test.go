@@ -0,0 +1,48 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_StrictKinds(t *testing.T) {
+	type S struct {
+		Val  int
+		Name string
+	}
+
+	t.Run("NotAllowed", func(t *testing.T) {
+		var visited []reflect.Kind
+		err := New(func(info *WalkInfo) error {
+			visited = append(visited, info.Value.Kind())
+			return nil
+		}).WithStrictKinds(reflect.Struct, reflect.Int).Walk(S{})
+		require.ErrorIs(t, err, ErrKindNotAllowed)
+		require.Contains(t, err.Error(), `".Name"`)
+		require.Equal(t, []reflect.Kind{reflect.Struct, reflect.Int}, visited)
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			return nil
+		}).WithStrictKinds(reflect.Struct, reflect.Int, reflect.String).Walk(S{}))
+	})
+}
+
+func TestWalker_SkipKinds(t *testing.T) {
+	type S struct {
+		Ptr  *int
+		Num  int
+		Str  string
+		List []*int
+	}
+	x := 1
+	val := S{Ptr: &x, Num: 2, Str: "s", List: []*int{&x}}
+
+	expected := []string{":struct", ".Num:int", ".List:slice"}
+	require.Equal(t, expected, walkKindPaths(t, New(nil).WithSkipKinds(reflect.Pointer, reflect.String), val))
+	//nolint:staticcheck
+	require.Equal(t, expected, walkKindPaths(t, New(nil).WithSkipKinds(reflect.Ptr, reflect.String), val))
+}
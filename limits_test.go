@@ -0,0 +1,152 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_MaxBytes(t *testing.T) {
+	val := []int{1, 2, 3, 4, 5}
+	intSize := int(unsafe.Sizeof(int(0)))
+
+	t.Run("Exceeded", func(t *testing.T) {
+		leaves := 0
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				leaves++
+			}
+			return nil
+		}).WithMaxBytes(sliceSize() + 2*intSize).Walk(val)
+		require.ErrorIs(t, err, ErrByteBudgetExceeded)
+		require.Equal(t, 2, leaves)
+	})
+
+	t.Run("Enough", func(t *testing.T) {
+		leaves := 0
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				leaves++
+			}
+			return nil
+		}).WithMaxBytes(sliceSize() + len(val)*intSize).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, len(val), leaves)
+	})
+}
+
+func TestWalker_MaxDepth(t *testing.T) {
+	type Inner struct {
+		Value int
+	}
+	type S struct {
+		Name  string
+		Inner Inner
+		Items []int
+		M     map[Inner]int
+	}
+	val := S{Name: "name", Inner: Inner{Value: 1}, Items: []int{2}, M: map[Inner]int{{Value: 3}: 4}}
+
+	t.Run("Depth1", func(t *testing.T) {
+		truncated := map[string]bool{}
+		err := New(func(info *WalkInfo) error {
+			truncated[info.Path()] = info.Truncated
+			return nil
+		}).WithMaxDepth(1).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{
+			"":       false,
+			".Name":  false,
+			".Inner": true,
+			".Items": true,
+			".M":     true,
+		}, truncated)
+	})
+
+	t.Run("MapKey", func(t *testing.T) {
+		truncated := map[string]bool{}
+		err := New(func(info *WalkInfo) error {
+			truncated[info.Path()] = info.Truncated
+			return nil
+		}).WithMaxDepth(2).Walk(val)
+		require.NoError(t, err)
+		require.True(t, truncated[".M{{3}}"])
+		require.False(t, truncated[".M[{3}]"])
+		require.Contains(t, truncated, ".M[{3}]")
+		require.Contains(t, truncated, ".Inner.Value")
+		require.NotContains(t, truncated, ".M{{3}}.Value")
+	})
+
+	t.Run("OncePerType", func(t *testing.T) {
+		type Pair struct {
+			A Inner
+			B Inner
+		}
+		var paths []string
+		err := New(func(info *WalkInfo) error {
+			paths = append(paths, info.Path())
+			return nil
+		}).WithOncePerType(true).WithMaxDepth(1).Walk(Pair{A: Inner{Value: 1}, B: Inner{Value: 2}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"", ".A"}, paths)
+	})
+
+	t.Run("SkipRoot", func(t *testing.T) {
+		var paths []string
+		err := New(func(info *WalkInfo) error {
+			paths = append(paths, info.Path())
+			return nil
+		}).WithSkipRoot(true).WithMaxDepth(1).Walk(&val)
+		require.NoError(t, err)
+		require.Equal(t, []string{".Name", ".Inner", ".Items", ".M"}, paths)
+	})
+
+	t.Run("Pointers", func(t *testing.T) {
+		type P struct {
+			Inner *Inner
+			Any   interface{}
+		}
+		truncated := map[string]bool{}
+		err := New(func(info *WalkInfo) error {
+			truncated[info.Path()+":"+info.Value.Kind().String()] = info.Truncated
+			return nil
+		}).WithMaxDepth(1).Walk(&P{Inner: &Inner{Value: 1}, Any: Inner{Value: 2}})
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{
+			":ptr":           false,
+			":struct":        false,
+			".Inner:ptr":     false,
+			".Inner:struct":  true,
+			".Any:interface": false,
+			".Any:struct":    true,
+		}, truncated)
+	})
+
+	t.Run("EmptyNotTruncated", func(t *testing.T) {
+		type E struct {
+			Empty  struct{}
+			Nil    []int
+			NilMap map[int]int
+			NilPtr *Inner
+			Arr    [0]int
+		}
+		err := New(func(info *WalkInfo) error {
+			require.False(t, info.Truncated, info.Path())
+			return nil
+		}).WithMaxDepth(1).Walk(E{})
+		require.NoError(t, err)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			require.False(t, info.Truncated)
+			cnt++
+			return nil
+		}).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, 10, cnt)
+	})
+}
@@ -0,0 +1,130 @@
+package objwalker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type linkedNode struct {
+	Val  int
+	Next *linkedNode
+}
+
+func newLinkedList(size int) *linkedNode {
+	var head *linkedNode
+	for i := 0; i < size; i++ {
+		head = &linkedNode{Val: i, Next: head}
+	}
+	return head
+}
+
+func TestWalker_VisitedCapacity(t *testing.T) {
+	list := newLinkedList(100)
+	list.Next.Next = list
+
+	for _, capacity := range []int{-1, 0, 1000} {
+		t.Run(fmt.Sprint(capacity), func(t *testing.T) {
+			var values []int
+			require.NoError(t, New(func(info *WalkInfo) error {
+				if info.Value.Kind() == reflect.Int {
+					values = append(values, int(info.Value.Int()))
+				}
+				return nil
+			}).WithVisitedCapacity(capacity).Walk(list))
+			require.Equal(t, []int{99, 98}, values)
+		})
+	}
+}
+
+func BenchmarkWalker_VisitedCapacity(b *testing.B) {
+	const size = 10000
+	list := newLinkedList(size)
+
+	for _, capacity := range []int{0, size * 3} {
+		b.Run(fmt.Sprint(capacity), func(b *testing.B) {
+			walker := New(func(info *WalkInfo) error {
+				return nil
+			}).WithVisitedCapacity(capacity)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = walker.Walk(list)
+			}
+		})
+	}
+}
+
+func TestWalker_ClosesCycleWith(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	type S struct {
+		First  *Node
+		Shared *Node
+	}
+
+	a := &Node{Name: "a"}
+	b := &Node{Name: "b", Next: a}
+	a.Next = b
+	val := S{First: a, Shared: b}
+
+	cycles := map[string]string{}
+	err := New(func(info *WalkInfo) error {
+		if info.IsVisited {
+			if info.ClosesCycleWith != nil {
+				cycles[info.Path()] = info.ClosesCycleWith.Path()
+			} else {
+				cycles[info.Path()] = "<nil>"
+			}
+			return ErrSkip
+		}
+		require.Nil(t, info.ClosesCycleWith)
+		return nil
+	}).WithLoopProtection(false).Walk(val)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		".First.Next.Next": ".First",
+		".Shared":          "<nil>",
+	}, cycles)
+
+	t.Run("ValueHash", func(t *testing.T) {
+		s := []interface{}{nil}
+		s[0] = s
+		var cycle []string
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithValueHashLoopProtection(true).WithOnLoopSkip(func(info *WalkInfo) {
+			require.NotNil(t, info.ClosesCycleWith)
+			cycle = append(cycle, info.Path()+"->"+info.ClosesCycleWith.Path())
+		}).Walk(s)
+		require.NoError(t, err)
+		require.Equal(t, []string{"[0]->"}, cycle)
+	})
+}
+
+func TestWalker_OnLoopSkip(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	node := &Node{Name: "a"}
+	node.Next = node
+
+	var skipped []*WalkInfo
+	err := New(func(info *WalkInfo) error {
+		return nil
+	}).WithOnLoopSkip(func(info *WalkInfo) {
+		skipped = append(skipped, info)
+	}).Walk(node)
+	require.NoError(t, err)
+
+	require.Len(t, skipped, 1)
+	require.Equal(t, ".Next", skipped[0].Path())
+	require.Equal(t, reflect.Struct, skipped[0].Value.Kind())
+	require.True(t, skipped[0].IsVisited)
+	require.Equal(t, ":struct", skipped[0].ClosesCycleWith.Path()+":"+skipped[0].ClosesCycleWith.Value.Kind().String())
+}
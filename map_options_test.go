@@ -0,0 +1,140 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_MapIterationSnapshot(t *testing.T) {
+	m := map[int]int{}
+	for i := 0; i < 10; i++ {
+		m[i] = i
+	}
+
+	keys := 0
+	require.NoError(t, New(func(info *WalkInfo) error {
+		if info.IsMapKey() {
+			keys++
+			m[int(info.Value.Int())+100] = 0
+			delete(m, int(info.Value.Int())+1)
+		}
+		return nil
+	}).WithMapIterationSnapshot(true).Walk(m))
+	require.Equal(t, 10, keys)
+}
+
+func TestWalker_MapKeyVisit(t *testing.T) {
+	val := map[int]string{1: "one"}
+	for _, test := range []struct {
+		name     string
+		mode     MapVisitMode
+		expected []interface{}
+	}{
+		{"KeyThenValue", MapKeyThenValue, []interface{}{1, "one"}},
+		{"ValueOnly", MapValueOnly, []interface{}{"one"}},
+		{"KeyOnly", MapKeyOnly, []interface{}{1}},
+		{"ValueThenKey", MapValueThenKey, []interface{}{"one", 1}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var visited []interface{}
+			require.NoError(t, New(func(info *WalkInfo) error {
+				if info.IsMapKey() || info.IsMapValue() {
+					visited = append(visited, info.Value.Interface())
+				}
+				return nil
+			}).WithMapKeyVisit(test.mode).Walk(val))
+			require.Equal(t, test.expected, visited)
+		})
+	}
+}
+
+func TestWalker_MapEntryMode(t *testing.T) {
+	val := map[int]string{1: "a", 2: "b"}
+
+	t.Run("Entries", func(t *testing.T) {
+		entries := map[int]string{}
+		var kinds []string
+		err := New(func(info *WalkInfo) error {
+			switch {
+			case info.IsMapEntry():
+				require.Equal(t, RoleMapEntry, info.Role())
+				require.Equal(t, info.EntryValue.Interface(), info.Value.Interface())
+				entries[int(info.EntryKey.Int())] = info.EntryValue.String()
+				kinds = append(kinds, "entry")
+			default:
+				require.False(t, info.EntryKey.IsValid())
+				require.False(t, info.EntryValue.IsValid())
+				kinds = append(kinds, info.Value.Kind().String())
+			}
+			return nil
+		}).WithMapEntryMode(true).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, val, entries)
+		require.Equal(t, []string{"map", "entry", "int", "string", "entry", "int", "string"}, kinds)
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		entries := 0
+		err := New(func(info *WalkInfo) error {
+			if info.IsMapEntry() {
+				entries++
+				return ErrSkip
+			}
+			require.Equal(t, reflect.Map, info.Value.Kind())
+			return nil
+		}).WithMapEntryMode(true).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, 2, entries)
+	})
+
+	t.Run("TypeHooks", func(t *testing.T) {
+		type Named struct {
+			Name string
+		}
+		m := map[string]Named{"k": {Name: "n"}}
+		entryKinds := func(info *WalkInfo) (string, bool) {
+			if info.IsMapEntry() {
+				return info.Path() + ":entry", true
+			}
+			return info.Path() + ":" + info.Value.Kind().String(), true
+		}
+
+		// Name is skipped as second string value, after key
+		require.Equal(t, []string{":map", "[k]:entry", "{k}:string", "[k]:struct"},
+			walkCollect(t, New(nil).WithMapEntryMode(true).WithOncePerType(true), m, entryKinds))
+
+		require.Equal(t, []string{":map", "[k]:entry", "{k}:string", "[k]:struct"},
+			walkCollect(t, New(nil).WithMapEntryMode(true).WithStopOnType(reflect.TypeOf(Named{})), m, entryKinds))
+
+		var hooked []string
+		walkCollect(t, New(nil).WithMapEntryMode(true).WithNamedTypeHook(func(info *WalkInfo) error {
+			hooked = append(hooked, info.Path())
+			return nil
+		}), m, entryKinds)
+		require.Equal(t, []string{"[k]"}, hooked)
+	})
+}
+
+func TestWalker_MapKeyTypeFilter(t *testing.T) {
+	type secretKey string
+	type S struct {
+		Secret map[secretKey]int
+		Public map[string]int
+	}
+	val := S{
+		Secret: map[secretKey]int{"password": 1, "token": 2},
+		Public: map[string]int{"name": 3},
+	}
+
+	var paths []string
+	err := New(func(info *WalkInfo) error {
+		paths = append(paths, info.Path())
+		return nil
+	}).WithMapKeyVisit(MapValueOnly).WithMapKeyTypeFilter(func(keyType reflect.Type) bool {
+		return keyType != reflect.TypeOf(secretKey(""))
+	}).Walk(val)
+	require.NoError(t, err)
+	require.Equal(t, []string{"", ".Secret", ".Public", ".Public[name]"}, paths)
+}
@@ -0,0 +1,50 @@
+package objwalker
+
+import (
+	"math/big"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+// isMathBig return true for big.Int, big.Float and pointers to them
+func isMathBig(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t == bigIntType || t == bigFloatType
+}
+
+// MathBigString return decimal representation of big.Int, big.Float or pointer to them and true.
+// Value of big.Int or big.Float (not pointer) must have DirectPointer.
+// It return "<nil>" for nil pointers and false for other values.
+func (w *WalkInfo) MathBigString() (string, bool) {
+	v := w.Value
+	if !isMathBig(v.Type()) {
+		return "", false
+	}
+
+	t := v.Type()
+	var ptr unsafe.Pointer
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "<nil>", true
+		}
+		t = t.Elem()
+		ptr = v.UnsafePointer()
+	} else {
+		if !w.HasDirectPointer() {
+			return "", false
+		}
+		ptr = w.DirectPointer
+	}
+
+	if t == bigIntType {
+		return (*big.Int)(ptr).String(), true
+	}
+	return (*big.Float)(ptr).String(), true
+}
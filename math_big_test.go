@@ -0,0 +1,65 @@
+package objwalker
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_MathBigAsLeaf(t *testing.T) {
+	type S struct {
+		Int      *big.Int
+		Float    *big.Float
+		IntValue big.Int
+		NilInt   *big.Int
+		private  *big.Int
+	}
+	val := &S{
+		Int:      big.NewInt(123),
+		Float:    big.NewFloat(1.5),
+		IntValue: *big.NewInt(-7),
+		private:  big.NewInt(42),
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		calls := map[string]int{}
+		texts := map[string]string{}
+		err := New(func(info *WalkInfo) error {
+			calls[info.Path()]++
+			if s, ok := info.MathBigString(); ok {
+				texts[info.Path()] = s
+			}
+			return nil
+		}).WithMathBigAsLeaf(true).Walk(val)
+		require.NoError(t, err)
+
+		require.Equal(t, map[string]int{"": 2, ".Int": 1, ".Float": 1, ".IntValue": 1, ".NilInt": 1, ".private": 1}, calls)
+		require.Equal(t, map[string]string{
+			".Int":      "123",
+			".Float":    "1.5",
+			".IntValue": "-7",
+			".NilInt":   "<nil>",
+			".private":  "42",
+		}, texts)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		calls := 0
+		err := New(func(info *WalkInfo) error {
+			calls++
+			return nil
+		}).Walk(val)
+		require.NoError(t, err)
+		require.Greater(t, calls, 8)
+	})
+
+	t.Run("NotMathBig", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			_, ok := info.MathBigString()
+			require.False(t, ok)
+			return nil
+		}).Walk(struct{ A int }{})
+		require.NoError(t, err)
+	})
+}
@@ -1,10 +1,14 @@
 package objwalker
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -13,9 +17,16 @@ var zeroPointer unsafe.Pointer
 var (
 	// ErrSkip - signal for skip iteration over value
 	// can be returned for array, interface, map, map key, slice, struct, ptr,
-	// for other kinds - unspecified behaviour and it may be change for feature versions
+	// for map key of simple kind - skip the map value too,
+	// for other simple kinds - it is no-op: walk continue with next value
 	ErrSkip = errors.New("skip value")
 
+	// ErrStop - signal for stop walk, Walk return nil error if callback return it
+	ErrStop = errors.New("stop walk")
+
+	// errCollected mean callback error was collected, handled as ErrSkip
+	errCollected = fmt.Errorf("error collected: %w", ErrSkip)
+
 	// ErrInvalidKind
 	errInvalidKind = errors.New("unexpected invalid kind")
 
@@ -24,6 +35,18 @@ var (
 
 	// ErrBadInternalReflectValueDetected
 	ErrBadInternalReflectValueDetected = errors.New("bad internal reflection.Value representation detected")
+
+	// ErrKindNotAllowed mean walker found value of kind, which isn't in Walker.StrictKinds list
+	ErrKindNotAllowed = errors.New("kind not allowed")
+
+	// ErrByteBudgetExceeded mean walker inspect more bytes, then allowed by Walker.MaxBytes
+	ErrByteBudgetExceeded = errors.New("byte budget exceeded")
+
+	// ErrCallbackErrorsTruncated mean walker collected Walker.MaxCallbackErrors errors and ignore next errors
+	ErrCallbackErrorsTruncated = errors.New("callback errors truncated")
+
+	// ErrCallbackPanic mean callback panic and walker recover it, see Walker.RecoverPanics
+	ErrCallbackPanic = errors.New("callback panic")
 )
 
 // WalkInfo send to walk callback with every value
@@ -41,8 +64,105 @@ type WalkInfo struct {
 	// IsVisited true if loop protection disabled and walker detect about value was visited already
 	IsVisited bool
 
+	// StructField is description of struct field if Value is field of struct, nil for other values
+	StructField *reflect.StructField
+
+	// Index is index of item in parent slice or array, -1 for other values
+	Index int
+
+	// SiblingIndex is index of value in parent struct fields, slice or array items, -1 for other values.
+	// For struct fields it is index of field in struct declaration.
+	SiblingIndex int
+
+	// SiblingCount is count of walked fields of parent struct (fields, allowed by Walker.FieldTagFilterKey)
+	// or items of parent slice or array, -1 for other values
+	SiblingCount int
+
+	// Cap is capacity of slice or array value (for array it equal to len), -1 for other kinds
+	Cap int
+
+	// EntryKey and EntryValue are key and value of map entry if walker visit map entry
+	// (see Walker.MapEntryMode), zero values for other values
+	EntryKey   reflect.Value
+	EntryValue reflect.Value
+
+	// IsProbe is true for synthetic zero values of item types of empty collections, see Walker.EmptyCollectionProbe
+	IsProbe bool
+
+	// IsFuncParam and IsFuncResult are true for synthetic zero values of func parameter and result types
+	// (see Walker.WalkFuncSignature), SiblingIndex is index of the parameter or result.
+	IsFuncParam  bool
+	IsFuncResult bool
+
+	// IsStringRune is true for runes of strings, see Walker.WalkStringRunes
+	IsStringRune bool
+
+	// IsMapInternals is true for synthetic MapInternals values, see Walker.ExposeMapInternals
+	IsMapInternals bool
+
+	// IsMethod is true for synthetic values of methods, see Walker.WalkMethods
+	IsMethod bool
+
+	// Method describe method for values with IsMethod flag, nil for other values.
+	// Method.Index is index of the method in method set of parent type, SiblingIndex of methods is -1.
+	Method *reflect.Method
+
+	// ClosesCycleWith is ancestor with same address and type as visited value (or same content hash,
+	// see Walker.ValueHashLoopProtection), if the value closes cycle, nil for other values. Callback receive visited values only if Walker.LoopProtection disabled.
+	ClosesCycleWith *WalkInfo
+
+	// PointerDepth is count of pointers and interfaces, collapsed before the value,
+	// see Walker.CollapsePointerChains
+	PointerDepth int
+
+	// Truncated is true for composite values, which has children, but children doesn't walked because of Walker.MaxDepth
+	Truncated bool
+
+	// ChanDir is direction of channel type for channel values, 0 for other kinds
+	ChanDir reflect.ChanDir
+
+	// ArrayLen is length of array type for array values, -1 for other kinds (slices too)
+	ArrayLen int
+
+	// MapLen is count of entries in map value (0 for nil map), -1 for other kinds
+	MapLen int
+
 	isMapValue bool
 	isMapKey   bool
+	isMapEntry bool
+
+	skipChildren bool
+
+	// readOnly is true if setters disabled by Walker.ReadOnly
+	readOnly bool
+
+	// keepMatched is true if the value or its ancestor matched by Walker.KeepPredicate
+	keepMatched bool
+
+	// keepID is id of value in walkerState.keepBranches, 0 if unknown
+	keepID int
+
+	// root is cache for Root method
+	root *WalkInfo
+
+	// mapKey is key of map entry for map keys and values, for describe value position
+	mapKey reflect.Value
+
+	// depth is count of ancestors of value in walk tree, it doesn't depend on Walker.ParentChainLimit
+	depth int
+
+	// logicalDepth is count of ancestors of value in walk tree, except pointers and interfaces, used for Walker.MaxDepth
+	logicalDepth int
+
+	// pathHash is hash of value, added to walkerState.hashVisited while walk over the value and its children
+	pathHash    uint64
+	hasPathHash bool
+
+	// limitedChainCache is cache of limitedChain result
+	limitedChainCache *WalkInfo
+
+	// keyFormatter is Walker.KeyFormatter, used by Path
+	keyFormatter func(key reflect.Value) string
 }
 
 // HasDirectPointer check if w.DirectPointer has non zero value
@@ -50,6 +170,30 @@ func (w *WalkInfo) HasDirectPointer() bool {
 	return w.DirectPointer != zeroPointer
 }
 
+// Root return info of first visited value of walk tree, it is w itself if w.Parent is nil
+func (w *WalkInfo) Root() *WalkInfo {
+	if w.root != nil {
+		return w.root
+	}
+	if w.Parent == nil {
+		return w
+	}
+	w.root = w.Parent.Root()
+	return w.root
+}
+
+// SkipChildren request walker to skip children of the value after callback return.
+// It is alternative to return ErrSkip from callback, but doesn't skip map value for map key.
+func (w *WalkInfo) SkipChildren() {
+	w.skipChildren = true
+}
+
+// TypeName return name of Value type: name of predeclared or defined type, empty for unnamed types (like []int).
+// It allow distinguish defined types like `type Celsius float64` from float64 with same Kind.
+func (w *WalkInfo) TypeName() string {
+	return w.Value.Type().Name()
+}
+
 // IsMapKey mean Value direct use as map key
 func (w *WalkInfo) IsMapKey() bool {
 	return w.isMapKey
@@ -60,11 +204,166 @@ func (w *WalkInfo) IsMapValue() bool {
 	return w.isMapValue
 }
 
+// IsMapEntry return true if value is map entry, see Walker.MapEntryMode
+func (w *WalkInfo) IsMapEntry() bool {
+	return w.isMapEntry
+}
+
+// IsNestedContainer mean Value is map, slice, array or struct and it is map value or item of slice or array
+func (w *WalkInfo) IsNestedContainer() bool {
+	//nolint:exhaustive
+	switch w.Value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return w.isMapValue || w.Index >= 0
+	default:
+		return false
+	}
+}
+
+// IsPointerTarget return true if value is element of parent pointer
+func (w *WalkInfo) IsPointerTarget() bool {
+	return w.Parent != nil && w.Parent.Value.Kind() == reflect.Pointer
+}
+
+// InterfaceStaticType return type of parent interface value for element of the interface,
+// for example io.Reader for value, stored in io.Reader field. It return nil if parent isn't interface.
+func (w *WalkInfo) InterfaceStaticType() reflect.Type {
+	if w.Parent == nil || w.Parent.Value.Kind() != reflect.Interface {
+		return nil
+	}
+	return w.Parent.Value.Type()
+}
+
+// IsTypedNil return true for nil pointer and for non nil interface, which contains nil pointer.
+// It return false for nil interface.
+func (w *WalkInfo) IsTypedNil() bool {
+	v := w.Value
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// Tag return value of struct tag key for struct field and true if the tag exists.
+// It return false for values, which aren't struct fields.
+func (w *WalkInfo) Tag(key string) (string, bool) {
+	if w.StructField == nil {
+		return "", false
+	}
+	return w.StructField.Tag.Lookup(key)
+}
+
+// FieldIndexPath return indexes of struct fields from nearest ancestor, which isn't struct field of struct,
+// to the value. Result is compatible with reflect.Value.FieldByIndex of the ancestor.
+// It return nil if the value isn't struct field.
+func (w *WalkInfo) FieldIndexPath() []int {
+	var res []int
+	for info := w; info.isDirectStructField(); info = info.Parent {
+		res = append(res, info.StructField.Index...)
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+// isDirectStructField return true if value is field of parent struct value,
+// false for other values, promoted fields of embedded interfaces (them has no SiblingIndex)
+// and elements of transparent pointers (them has type of element, not field)
+func (w *WalkInfo) isDirectStructField() bool {
+	return w.StructField != nil && w.SiblingIndex >= 0 && w.Parent != nil &&
+		w.Parent.Value.Kind() == reflect.Struct && w.StructField.Type == w.Value.Type()
+}
+
+// Container return value of parent struct, array, slice or map (for map keys and values) and true.
+// It return false for root value and elements of pointers and interfaces.
+func (w *WalkInfo) Container() (reflect.Value, bool) {
+	if w.Parent == nil {
+		return reflect.Value{}, false
+	}
+
+	//nolint:exhaustive
+	switch w.Parent.Value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
+		return w.Parent.Value, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// ChildTypes return static types of value children without walk into them:
+// field types for struct, item type for slice and array, key and value types for map, element type for pointer
+// and dynamic type for non nil interface. It return nil for other values.
+func (w *WalkInfo) ChildTypes() []reflect.Type {
+	t := w.Value.Type()
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Struct:
+		res := make([]reflect.Type, t.NumField())
+		for i := range res {
+			res[i] = t.Field(i).Type
+		}
+		return res
+	case reflect.Slice, reflect.Array, reflect.Pointer:
+		return []reflect.Type{t.Elem()}
+	case reflect.Map:
+		return []reflect.Type{t.Key(), t.Elem()}
+	case reflect.Interface:
+		if w.Value.IsNil() {
+			return nil
+		}
+		return []reflect.Type{w.Value.Elem().Type()}
+	default:
+		return nil
+	}
+}
+
+// MapVisitMode describe which parts of map entries walker visit and order of them
+type MapVisitMode int
+
+const (
+	// MapKeyThenValue - visit key, then value of every map entry (default)
+	MapKeyThenValue MapVisitMode = iota
+
+	// MapValueOnly - visit map values only
+	MapValueOnly
+
+	// MapKeyOnly - visit map keys only
+	MapKeyOnly
+
+	// MapValueThenKey - visit value, then key of every map entry
+	MapValueThenKey
+)
+
+// FieldOrder describe order of visit struct fields
+type FieldOrder int
+
+const (
+	// FieldOrderDeclaration - visit fields in order of declaration (default)
+	FieldOrderDeclaration FieldOrder = iota
+
+	// FieldOrderAlphabetical - visit fields sorted by name
+	FieldOrderAlphabetical
+
+	// FieldOrderReverse - visit fields in reverse order of declaration
+	FieldOrderReverse
+)
+
 // WalkFunc is type of callback function
 type WalkFunc func(info *WalkInfo) error
 
 type empty struct{}
 
+// addressTypeKey identify value by address and type, same as loop protection
+type addressTypeKey struct {
+	ptr unsafe.Pointer
+	t   reflect.Type
+}
+
 // Walker provide settings and state for Walk function
 // default values set with New func
 type Walker struct {
@@ -77,6 +376,303 @@ type Walker struct {
 	// default - false
 	UnsafeReadDirectPtr bool
 
+	// MaxBytes limit summary size of walked values, 0 mean no limit.
+	// Every value count own bytes only: headers for slices, strings, maps, ..., full size for simple values,
+	// zero for structs and arrays - because them data counted by fields and items.
+	// Walk return ErrByteBudgetExceeded when limit exceeded
+	MaxBytes int
+
+	// NamedTypeHook if not nil - called before callback for values of named types, declared in packages
+	// (types with non empty Name and PkgPath). Error of the hook handled same as callback error.
+	NamedTypeHook WalkFunc
+
+	// ValueHashLoopProtection if true - loop protection detect repeats of unaddressable composite values
+	// (without DirectPointer) by hash of them content. Pointer-like values hashed by address of data.
+	// Only hashes of ancestors are checked, so equal values in different branches are visited.
+	// It is best-effort: hash collision with ancestor can skip value. Too big values are not protected. Default false.
+	ValueHashLoopProtection bool
+
+	// CollectErrors if true - callback errors (except ErrSkip) doesn't stop walk, but collected and returned
+	// from Walk as joined error after walk finished. Children of value with error are skipped, as for ErrSkip.
+	// Default false.
+	CollectErrors bool
+
+	// VisitedCapacity is initial capacity of visited values map for loop protection.
+	// It is performance hint for walk over big objects only, it doesn't change walk behaviour.
+	// Non positive value mean default capacity.
+	VisitedCapacity int
+
+	// SkipFunc if not nil - called before callback for every value, if it return true -
+	// the value and its children skipped without callback call
+	SkipFunc func(info *WalkInfo) bool
+
+	// MapIterationSnapshot if true - walker copy all map entries before walk over them.
+	// It allow callback to add and remove map entries without affect to iteration:
+	// walker visit entries, which were in map before walk into the map.
+	// Default false.
+	MapIterationSnapshot bool
+
+	// MapKeyVisit control which parts of map entries walker visit and order of them, default MapKeyThenValue.
+	// ErrSkip from key skip the value for MapKeyThenValue mode only
+	MapKeyVisit MapVisitMode
+
+	// TransparentPointers if true - callback doesn't called for pointers, walker go to pointed value directly.
+	// Parent of pointed value is parent of the pointer, pointed value has StructField and Index of the pointer.
+	// Nil pointers skipped.
+	// Loop protection work same as without the option. Default false.
+	TransparentPointers bool
+
+	// StrictKinds if not empty - list of allowed kinds of values,
+	// walk stop with ErrKindNotAllowed error, contains path of value, when found value of other kind
+	StrictKinds []reflect.Kind
+
+	// ForceInterfaceable if true - addressable values of unexported fields (and them children) send to callback
+	// as values, created by reflect.NewAt from DirectPointer. It allow call Value.Interface() and
+	// Value.Set() for the values without panic. Default false.
+	ForceInterfaceable bool
+
+	// RespectWalkable if true - walker use Walkable.WalkChildren result as children of values, which implement
+	// Walkable interface, instead of reflection walk over them. Pointers and interfaces walked as usual,
+	// WalkChildren called for they elements, so loop protection work for children, returned by WalkChildren.
+	// Default false.
+	RespectWalkable bool
+
+	// InterfaceResolver if not nil - called for non nil interface values after callback.
+	// If it return ok - walker use returned value instead of interface dynamic value as element of the interface.
+	// Invalid returned value mean no element.
+	InterfaceResolver func(info *WalkInfo) (reflect.Value, bool)
+
+	// Snapshot if true - Walk make deep copy of object before walk and walk over the copy (see DeepCopy).
+	// Copy made by walk over original object, so it doesn't protect from concurrent changes of the object,
+	// it need same synchronization as walk over the object.
+	// Copy contains only data, accessible by reflection: unexported chans, funcs and unsafe pointers
+	// will be zero in the copy. Changes of values from callback doesn't affect original object.
+	// Default false.
+	Snapshot bool
+
+	// SkipRoot if true - callback doesn't called for root value and, if root is pointer or interface,
+	// for values pointed by root, but walk continue to children of them. Default false.
+	SkipRoot bool
+
+	// VisitedMaxEntries if positive - limit count of values, remembered by loop protection.
+	// When the limit exceeded - walker free remembered values and disable loop protection (and IsVisited detection)
+	// for rest of walk, callback must self-detect loops after it. WalkStats.LoopProtectionDisabled report about it.
+	VisitedMaxEntries int
+
+	// Profiling if true - walker measure time of callback calls, summary time for every kind
+	// returned in WalkStats.CallbackDurationByKind. Default false.
+	Profiling bool
+
+	// StopOnType if not nil - walker stop walk after callback called for first value of the type,
+	// same as callback return ErrStop for it. Default nil.
+	StopOnType reflect.Type
+
+	// RecoverPanics if true - walker recover panics in callback and named type hook and handle it as callback error,
+	// which wrap ErrCallbackPanic, contains panic value and path of value. If panic value is error - it wrapped too.
+	// Default false.
+	RecoverPanics bool
+
+	// ReverseSliceOrder if true - walker visit items of slices and arrays from last to first.
+	// WalkInfo.Index of items still index in the slice. Default false.
+	ReverseSliceOrder bool
+
+	// FieldTagFilterKey if not empty - walker visit only struct fields with tag FieldTagFilterKey,
+	// value of the tag must be equal to FieldTagFilterValue, if FieldTagFilterValue is not empty.
+	// Skipped fields and their children doesn't sent to callback. Default empty.
+	FieldTagFilterKey string
+
+	// FieldTagFilterValue is required value of FieldTagFilterKey tag, see Walker.FieldTagFilterKey
+	FieldTagFilterValue string
+
+	// StringInterning if true - walker count occurrences of every distinct string value,
+	// counts returned in WalkStats.StringCounts. Walked strings doesn't changed. Default false.
+	StringInterning bool
+
+	// StructFieldOrder control order of visit struct fields, default FieldOrderDeclaration.
+	// WalkInfo.SiblingIndex of fields is index of field in struct declaration for any order.
+	StructFieldOrder FieldOrder
+
+	// MathBigAsLeaf if true - walker handle big.Int, big.Float and pointers to them as simple values:
+	// callback called for them, but walker doesn't walk into internal fields. Value of the numbers
+	// available by WalkInfo.MathBigString. Default false.
+	MathBigAsLeaf bool
+
+	// UnwrapErrors if true - walker use result of Unwrap() error or Unwrap() []error methods as children of values,
+	// which implement it, instead of reflection walk over them. Loop protection work for errors, which
+	// implement the methods with pointer receiver. Default false.
+	UnwrapErrors bool
+
+	// OncePerType if true - callback called for first value of every type only,
+	// walker still walk into children of other values of the type. Default false.
+	OncePerType bool
+
+	// KeyFormatter if not nil - used by WalkInfo.Path for text of map keys instead of fmt.Sprint. Default nil.
+	KeyFormatter func(key reflect.Value) string
+
+	// IgnoreUnknownKinds if true - walker handle values of unknown kinds (from future go versions) as simple values:
+	// call callback without walk into them, instead of return ErrUnknownKind. Default false.
+	IgnoreUnknownKinds bool
+
+	// ParentChainLimit if positive - limit count of ancestors, available by WalkInfo.Parent links from every value:
+	// values deeper than the limit see from ParentChainLimit to 2*ParentChainLimit-1 nearest ancestors,
+	// far ancestors are copies with cut Parent link, shared by many values. WalkInfo of ancestors doesn't changed.
+	// It reduce memory, retained by stored WalkInfo, but WalkInfo.Path, WalkInfo.Root and WalkInfo.Container
+	// see retained part of chain only and ClosesCycleWith detect cycles up to the limit. Default 0 - unlimited.
+	ParentChainLimit int
+
+	// PromoteEmbeddedInterfaces if true - walker walk over fields of struct (or pointer to struct) in embedded
+	// interface field as over fields of struct with the interface field, like promoted fields.
+	// Callback doesn't called for the interface field and dynamic value of it.
+	// Promoted fields has StructField of the dynamic value struct and SiblingIndex and SiblingCount -1,
+	// because them aren't fields of walked struct.
+	// Embedded interfaces with nil or other dynamic values walked as usual fields. Default false.
+	PromoteEmbeddedInterfaces bool
+
+	// LeafBatchFunc if not nil - walker send values of simple kinds (leaves) to LeafBatchFunc by batches
+	// of LeafBatchSize values instead of callback, rest of leaves sent after walk. Composite values still sent
+	// to callback. Error of LeafBatchFunc handled same as callback error. Default nil.
+	LeafBatchFunc func(infos []*WalkInfo) error
+
+	// LeafBatchSize is max size of leaves batch, see Walker.LeafBatchFunc. Values less then 1 handled as 1.
+	LeafBatchSize int
+
+	// MapEntryMode if true - walker call callback for every map entry before walk over key and value of it,
+	// WalkInfo.EntryKey and WalkInfo.EntryValue contains key and value of the entry and WalkInfo.Value is value
+	// of the entry. ErrSkip from the callback skip key and value of the entry.
+	// Walker.OncePerType, Walker.NamedTypeHook and Walker.StopOnType doesn't applied to entries,
+	// them applied to map value itself only. Default false.
+	MapEntryMode bool
+
+	// OnError if not nil - called for every error of callback, named type hook or LeafBatchFunc except ErrSkip
+	// and ErrStop before the error handled by walker. info is nil for errors of LeafBatchFunc. Default nil.
+	OnError func(info *WalkInfo, err error)
+
+	// FollowReflectValue if true - walker walk over value, wrapped by reflect.Value, instead of internal fields of
+	// reflect.Value. Callback called for reflect.Value itself, then for wrapped value as child of it.
+	// Invalid reflect.Value has no children. Default false.
+	FollowReflectValue bool
+
+	// EmptyCollectionProbe if true - walker call callback for zero values of item types of empty or nil slices
+	// and arrays and zero values of key and value types of empty or nil maps with WalkInfo.IsProbe flag.
+	// Walker doesn't walk into the probe values. Default false.
+	EmptyCollectionProbe bool
+
+	// InterfaceTypeCallback if not nil - called for non nil interface values with dynamic type of the value
+	// after callback and before walk into the interface element. ErrSkip from it skip the element,
+	// other errors handled same as callback errors. Default nil.
+	InterfaceTypeCallback func(info *WalkInfo, t reflect.Type) error
+
+	// AllowedPackages if not empty - walker walk into values of types from the packages and their subpackages only
+	// (for example "example.com/foo" allow "example.com/foo/bar", but not "example.com/foobar"), values of types from other packages handled as simple values: callback called for them, but walker doesn't
+	// walk into them. Types with empty package path (predeclared and unnamed types) always allowed. Default nil.
+	AllowedPackages []string
+
+	// WalkInfoPooling if true - walker reuse WalkInfo objects after walk over value and it's children finished.
+	// It reduce allocations, but callback must not use WalkInfo (and it's Parent chain) after walk over the value
+	// finished: store WalkInfo, use it from Iterator after next call, etc.
+	// Pooling doesn't work if LeafBatchFunc set. Default false.
+	WalkInfoPooling bool
+
+	// MaxCallbackErrors if positive - limit count of errors, collected if CollectErrors enabled.
+	// After the limit walker add ErrCallbackErrorsTruncated to collected errors and ignore next errors
+	// (walk continue, children of values with errors still skipped). Default 0 - unlimited.
+	MaxCallbackErrors int
+
+	// WalkFuncSignature if true - walker call callback for zero values of parameter and result types
+	// of func values after callback for the func, with WalkInfo.IsFuncParam or WalkInfo.IsFuncResult flags.
+	// Walker doesn't walk into the zero values. Default false.
+	WalkFuncSignature bool
+
+	// CanonicalizeFunc if not nil - called for every value before callback, if it return ok and same key returned
+	// for other value before - walker mark the value as visited and handle it same as value, detected by
+	// loop protection. It allow skip logically equal values with different addresses.
+	// Keys must be comparable. Default nil.
+	CanonicalizeFunc func(info *WalkInfo) (key interface{}, ok bool)
+
+	// OnLoopSkip if not nil - called for values, which skipped by loop protection. Default nil.
+	OnLoopSkip func(info *WalkInfo)
+
+	// SliceStreaming if true - walker detach WalkInfo of slice items after walk over item and it's children:
+	// clear Value, DirectPointer and Parent of it. So WalkInfo of items, stored by callback, doesn't retain
+	// slice memory, but useless after walk over the item.
+	// WalkInfo of items doesn't detached if WalkInfoPooling or LeafBatchFunc are used. Default false.
+	SliceStreaming bool
+
+	// MapKeyTypeFilter if not nil - called with key type of every walked map,
+	// if it return false - walker skip all entries of the map. Map value itself send to callback as usual.
+	// Default nil.
+	MapKeyTypeFilter func(keyType reflect.Type) bool
+
+	// WalkStringRunes if true - walker send to callback every rune of string values after callback for the string,
+	// as int32 values with WalkInfo.IsStringRune flag, WalkInfo.Index is index of rune in string (not byte offset).
+	// Default false - strings are leaves.
+	WalkStringRunes bool
+
+	// TypeRegistry if not nil - dynamic types of non nil interface values (after Walker.InterfaceResolver)
+	// must be registered in it, walk stop with ErrUnregisteredType error, contains path of value, for other types.
+	// Default nil.
+	TypeRegistry *TypeRegistry
+
+	// ExposeMapInternals if true - walker send to callback synthetic MapInternals value with WalkInfo.IsMapInternals
+	// flag after callback for every non nil map with DirectPointer. It is for low-level debugging only.
+	// With swiss table maps (default since go1.24) only MapInternals.Count is filled. Default false.
+	ExposeMapInternals bool
+
+	// SkipChanDir if not zero - walker skip channel values with the direction, callback doesn't called for them.
+	// Default 0 - walk over channels of all directions.
+	SkipChanDir reflect.ChanDir
+
+	// KeepPredicate if not nil - walker send to callback only values, for which the predicate return true,
+	// them descendants and them ancestors. Other branches pruned: callback doesn't called for them and them children.
+	// Walker look ahead over subtree for find matches once, results reused for descendants of the subtree,
+	// so predicate called at most twice for most values.
+	// Default nil.
+	KeepPredicate func(info *WalkInfo) bool
+
+	// SkipKinds if not empty - walker skip values of the kinds with them children, callback doesn't called for them.
+	// Default nil.
+	SkipKinds []reflect.Kind
+
+	// FieldRewriter if not nil - called for every struct field before walk over it,
+	// if it return true - walker write returned value to the field by WalkInfo.TrySet and walk over new value.
+	// Walk stop with error if the value can't be written, for example for fields of not addressable structs.
+	// Default nil.
+	FieldRewriter func(info *WalkInfo) (reflect.Value, bool)
+
+	// MaxDepth if greater than zero - walker doesn't walk children of values at the depth (root has depth 0),
+	// non-empty composite values at the depth has WalkInfo.Truncated flag.
+	// Pointers and interfaces doesn't increase depth: they are walked with their elements. Default 0 - unlimited.
+	MaxDepth int
+
+	// ReadOnly if true - setters of WalkInfo (TrySet, SetZero, SetInt and others) return ErrReadOnly
+	// instead of change value, CanModify return false. It doesn't protect from change values by reflection directly.
+	// Default false.
+	ReadOnly bool
+
+	// CollapsePointerChains if true - walker follow chains of pointers and interfaces to first not pointer value
+	// (or to nil pointer or interface) and call callback for the value only, with count of followed levels in
+	// WalkInfo.PointerDepth. Position of the value (parent, struct field, index, map key) is position of chain start.
+	// Walker.InterfaceTypeCallback, Walker.InterfaceResolver and Walker.TypeRegistry applied to interfaces of chain,
+	// WalkInfo of the interfaces, passed to them, has position of chain start. Nil pointer at end of chain skipped
+	// if Walker.TransparentPointers enabled. Default false.
+	CollapsePointerChains bool
+
+	// Timeout if greater than zero - max duration of walk, walker check it before every callback
+	// and stop walk with ErrTimeout when time exceeded. Default 0 - unlimited.
+	Timeout time.Duration
+
+	// WalkMethods if true - walker send to callback exported methods of struct and pointer values
+	// after callback for the value, as synthetic values with WalkInfo.IsMethod flag and WalkInfo.Method description.
+	// Value of them is method value, bound to receiver, walker doesn't call methods.
+	// Methods of struct, pointed by walked pointer, doesn't sent again: method set of the pointer contains them.
+	// Default false.
+	WalkMethods bool
+
+	// SkipValue if not nil - walker skip leaf values (not structs, arrays, slices, maps, pointers and interfaces),
+	// which equal to it by reflect.DeepEqual. Values of unexported fields compared if them have DirectPointer.
+	// Default nil.
+	SkipValue interface{}
+
 	callback WalkFunc
 }
 
@@ -88,21 +684,131 @@ type Walker struct {
 // f will called for struct T and for Pub int
 //
 // if f return ErrSkip - skip the struct (, map, slice, ... see ErrSkip comment)
+// if f return ErrStop - stop walk and return nil to walk caller
 // if f return other non nil error - stop walk and return the error to walk caller
 func New(f WalkFunc) *Walker {
 	return &Walker{
-		LoopProtection:      true,
-		UnsafeReadDirectPtr: false,
-		callback:            f,
+		LoopProtection:            true,
+		UnsafeReadDirectPtr:       false,
+		MaxBytes:                  0,
+		NamedTypeHook:             nil,
+		ValueHashLoopProtection:   false,
+		CollectErrors:             false,
+		VisitedCapacity:           0,
+		SkipFunc:                  nil,
+		MapIterationSnapshot:      false,
+		MapKeyVisit:               MapKeyThenValue,
+		TransparentPointers:       false,
+		StrictKinds:               nil,
+		ForceInterfaceable:        false,
+		RespectWalkable:           false,
+		InterfaceResolver:         nil,
+		Snapshot:                  false,
+		SkipRoot:                  false,
+		VisitedMaxEntries:         0,
+		Profiling:                 false,
+		StopOnType:                nil,
+		RecoverPanics:             false,
+		ReverseSliceOrder:         false,
+		FieldTagFilterKey:         "",
+		FieldTagFilterValue:       "",
+		StringInterning:           false,
+		StructFieldOrder:          FieldOrderDeclaration,
+		MathBigAsLeaf:             false,
+		UnwrapErrors:              false,
+		OncePerType:               false,
+		KeyFormatter:              nil,
+		IgnoreUnknownKinds:        false,
+		ParentChainLimit:          0,
+		PromoteEmbeddedInterfaces: false,
+		LeafBatchFunc:             nil,
+		LeafBatchSize:             0,
+		MapEntryMode:              false,
+		OnError:                   nil,
+		FollowReflectValue:        false,
+		EmptyCollectionProbe:      false,
+		InterfaceTypeCallback:     nil,
+		AllowedPackages:           nil,
+		WalkInfoPooling:           false,
+		MaxCallbackErrors:         0,
+		WalkFuncSignature:         false,
+		CanonicalizeFunc:          nil,
+		OnLoopSkip:                nil,
+		SliceStreaming:            false,
+		MapKeyTypeFilter:          nil,
+		WalkStringRunes:           false,
+		TypeRegistry:              nil,
+		ExposeMapInternals:        false,
+		SkipChanDir:               0,
+		KeepPredicate:             nil,
+		SkipKinds:                 nil,
+		FieldRewriter:             nil,
+		MaxDepth:                  0,
+		ReadOnly:                  false,
+		CollapsePointerChains:     false,
+		Timeout:                   0,
+		WalkMethods:               false,
+		SkipValue:                 nil,
+		callback:                  f,
 	}
 }
 
 // Walk create new walker with empty state and run Walk over object
+// Nil, nil interfaces and zero reflect.Value are no-op: callback doesn't called for them.
 func (w Walker) Walk(v interface{}) error {
 	walker := newWalkerState(w)
 	return walker.walk(v, checkValue())
 }
 
+// WalkAll walk over every of vs in order with common loop protection:
+// value, visited from one root, is visited for next roots too. Callback can stop walk over all roots by ErrStop.
+func (w Walker) WalkAll(vs ...interface{}) error {
+	state := newWalkerState(w)
+	if state.UnsafeReadDirectPtr && !checkValue() {
+		return ErrBadInternalReflectValueDetected
+	}
+
+	var err error
+	for _, v := range vs {
+		var rv reflect.Value
+		var ok bool
+		rv, ok, err = state.rootValue(v)
+		if err != nil {
+			break
+		}
+		if !ok {
+			continue
+		}
+		if err = state.walkValue(state.newWalkerInfo(rv, nil)); err != nil {
+			break
+		}
+	}
+	return state.finishWalk(err)
+}
+
+// WalkTransform walk over addressable copy of v and return the copy after walk.
+// It allow callback to replace root value (for example by WalkInfo.TrySet) and change fields of struct,
+// passed by value. Values, available through pointers, changed in original object (or in snapshot with Walker.Snapshot).
+// Nil, nil interfaces and zero reflect.Value are no-op, as for Walk: v returned as is.
+func (w Walker) WalkTransform(v interface{}) (interface{}, error) {
+	walker := newWalkerState(w)
+	if walker.UnsafeReadDirectPtr && !checkValue() {
+		return v, ErrBadInternalReflectValueDetected
+	}
+
+	rv, ok, err := walker.rootValue(v)
+	if err != nil || !ok {
+		return v, err
+	}
+
+	root := reflect.New(rv.Type()).Elem()
+	root.Set(rv)
+	if err = walker.walkRoot(root); err != nil {
+		return v, err
+	}
+	return root.Interface(), nil
+}
+
 func (w *Walker) WithUnsafeReadDirectPtr(val bool) *Walker {
 	w.UnsafeReadDirectPtr = val
 	return w
@@ -115,30 +821,469 @@ func (w *Walker) WithLoopProtection(val bool) *Walker {
 	return w
 }
 
+// WithMaxBytes set limit of walked bytes, see Walker.MaxBytes
+func (w *Walker) WithMaxBytes(n int) *Walker {
+	w.MaxBytes = n
+	return w
+}
+
+// WithNamedTypeHook set hook for values of named types, see Walker.NamedTypeHook
+func (w *Walker) WithNamedTypeHook(f WalkFunc) *Walker {
+	w.NamedTypeHook = f
+	return w
+}
+
+// WithValueHashLoopProtection enable loop protection for unaddressable values, see Walker.ValueHashLoopProtection
+func (w *Walker) WithValueHashLoopProtection(val bool) *Walker {
+	w.ValueHashLoopProtection = val
+	return w
+}
+
+// WithCollectErrors enable collect callback errors instead of stop walk, see Walker.CollectErrors
+func (w *Walker) WithCollectErrors(val bool) *Walker {
+	w.CollectErrors = val
+	return w
+}
+
+// WithVisitedCapacity set initial capacity of visited values map, see Walker.VisitedCapacity
+func (w *Walker) WithVisitedCapacity(n int) *Walker {
+	w.VisitedCapacity = n
+	return w
+}
+
+// WithSkipFunc set predicate for skip values, see Walker.SkipFunc
+func (w *Walker) WithSkipFunc(f func(info *WalkInfo) bool) *Walker {
+	w.SkipFunc = f
+	return w
+}
+
+// WithMapIterationSnapshot enable copy map entries before walk, see Walker.MapIterationSnapshot
+func (w *Walker) WithMapIterationSnapshot(val bool) *Walker {
+	w.MapIterationSnapshot = val
+	return w
+}
+
+// WithMapKeyVisit set mode of visit map entries, see Walker.MapKeyVisit
+func (w *Walker) WithMapKeyVisit(mode MapVisitMode) *Walker {
+	w.MapKeyVisit = mode
+	return w
+}
+
+// WithTransparentPointers enable skip callback for pointers, see Walker.TransparentPointers
+func (w *Walker) WithTransparentPointers(val bool) *Walker {
+	w.TransparentPointers = val
+	return w
+}
+
+// WithStrictKinds set list of allowed kinds, see Walker.StrictKinds
+func (w *Walker) WithStrictKinds(allowed ...reflect.Kind) *Walker {
+	w.StrictKinds = allowed
+	return w
+}
+
+// WithForceInterfaceable enable convert unexported fields to interfaceable values, see Walker.ForceInterfaceable
+func (w *Walker) WithForceInterfaceable(val bool) *Walker {
+	w.ForceInterfaceable = val
+	return w
+}
+
+// WithRespectWalkable enable use Walkable interface for get children, see Walker.RespectWalkable
+func (w *Walker) WithRespectWalkable(val bool) *Walker {
+	w.RespectWalkable = val
+	return w
+}
+
+// WithInterfaceResolver set resolver of interface elements, see Walker.InterfaceResolver
+func (w *Walker) WithInterfaceResolver(f func(info *WalkInfo) (reflect.Value, bool)) *Walker {
+	w.InterfaceResolver = f
+	return w
+}
+
+// WithSnapshot enable walk over deep copy of object, see Walker.Snapshot
+func (w *Walker) WithSnapshot(val bool) *Walker {
+	w.Snapshot = val
+	return w
+}
+
+// WithSkipRoot disable callback for root value, see Walker.SkipRoot
+func (w *Walker) WithSkipRoot(val bool) *Walker {
+	w.SkipRoot = val
+	return w
+}
+
+// WithVisitedMaxEntries set limit of remembered values for loop protection, see Walker.VisitedMaxEntries
+func (w *Walker) WithVisitedMaxEntries(n int) *Walker {
+	w.VisitedMaxEntries = n
+	return w
+}
+
+// WithProfiling enable measure callback time, see Walker.Profiling
+func (w *Walker) WithProfiling(val bool) *Walker {
+	w.Profiling = val
+	return w
+}
+
+// WithStopOnType set type, which stop walk after callback, see Walker.StopOnType
+func (w *Walker) WithStopOnType(t reflect.Type) *Walker {
+	w.StopOnType = t
+	return w
+}
+
+// WithRecoverPanics enable convert callback panics to errors, see Walker.RecoverPanics
+func (w *Walker) WithRecoverPanics(val bool) *Walker {
+	w.RecoverPanics = val
+	return w
+}
+
+// WithReverseSliceOrder enable visit slice and array items from last to first, see Walker.ReverseSliceOrder
+func (w *Walker) WithReverseSliceOrder(val bool) *Walker {
+	w.ReverseSliceOrder = val
+	return w
+}
+
+// WithFieldTagFilter set filter of struct fields by tag, see Walker.FieldTagFilterKey
+func (w *Walker) WithFieldTagFilter(key, value string) *Walker {
+	w.FieldTagFilterKey = key
+	w.FieldTagFilterValue = value
+	return w
+}
+
+// WithStringInterning enable count of distinct strings, see Walker.StringInterning
+func (w *Walker) WithStringInterning(val bool) *Walker {
+	w.StringInterning = val
+	return w
+}
+
+// WithStructFieldOrder set order of visit struct fields, see Walker.StructFieldOrder
+func (w *Walker) WithStructFieldOrder(order FieldOrder) *Walker {
+	w.StructFieldOrder = order
+	return w
+}
+
+// WithMathBigAsLeaf enable handle math/big numbers as simple values, see Walker.MathBigAsLeaf
+func (w *Walker) WithMathBigAsLeaf(val bool) *Walker {
+	w.MathBigAsLeaf = val
+	return w
+}
+
+// WithUnwrapErrors enable walk over wrapped errors, see Walker.UnwrapErrors
+func (w *Walker) WithUnwrapErrors(val bool) *Walker {
+	w.UnwrapErrors = val
+	return w
+}
+
+// WithOncePerType enable call callback once for every type, see Walker.OncePerType
+func (w *Walker) WithOncePerType(val bool) *Walker {
+	w.OncePerType = val
+	return w
+}
+
+// WithKeyFormatter set formatter of map keys for WalkInfo.Path, see Walker.KeyFormatter
+func (w *Walker) WithKeyFormatter(f func(key reflect.Value) string) *Walker {
+	w.KeyFormatter = f
+	return w
+}
+
+// WithIgnoreUnknownKinds enable handle unknown kinds as simple values, see Walker.IgnoreUnknownKinds
+func (w *Walker) WithIgnoreUnknownKinds(val bool) *Walker {
+	w.IgnoreUnknownKinds = val
+	return w
+}
+
+// WithParentChainLimit set limit of WalkInfo.Parent chain length, see Walker.ParentChainLimit
+func (w *Walker) WithParentChainLimit(n int) *Walker {
+	w.ParentChainLimit = n
+	return w
+}
+
+// WithPromoteEmbeddedInterfaces enable walk over fields of embedded interfaces as over promoted fields,
+// see Walker.PromoteEmbeddedInterfaces
+func (w *Walker) WithPromoteEmbeddedInterfaces(val bool) *Walker {
+	w.PromoteEmbeddedInterfaces = val
+	return w
+}
+
+// WithLeafBatch set batch callback for leaves, see Walker.LeafBatchFunc
+func (w *Walker) WithLeafBatch(n int, f func(infos []*WalkInfo) error) *Walker {
+	w.LeafBatchSize = n
+	w.LeafBatchFunc = f
+	return w
+}
+
+// WithMapEntryMode enable callback for map entries, see Walker.MapEntryMode
+func (w *Walker) WithMapEntryMode(val bool) *Walker {
+	w.MapEntryMode = val
+	return w
+}
+
+// WithOnError set observer of callback errors, see Walker.OnError
+func (w *Walker) WithOnError(f func(info *WalkInfo, err error)) *Walker {
+	w.OnError = f
+	return w
+}
+
+// WithFollowReflectValue enable walk into values, wrapped by reflect.Value, see Walker.FollowReflectValue
+func (w *Walker) WithFollowReflectValue(val bool) *Walker {
+	w.FollowReflectValue = val
+	return w
+}
+
+// WithEmptyCollectionProbe enable probe values for empty collections, see Walker.EmptyCollectionProbe
+func (w *Walker) WithEmptyCollectionProbe(val bool) *Walker {
+	w.EmptyCollectionProbe = val
+	return w
+}
+
+// WithInterfaceTypeCallback set callback for dynamic types of interfaces, see Walker.InterfaceTypeCallback
+func (w *Walker) WithInterfaceTypeCallback(f func(info *WalkInfo, t reflect.Type) error) *Walker {
+	w.InterfaceTypeCallback = f
+	return w
+}
+
+// WithAllowedPackages set packages (with subpackages) of types for walk into, see Walker.AllowedPackages
+func (w *Walker) WithAllowedPackages(packages ...string) *Walker {
+	w.AllowedPackages = packages
+	return w
+}
+
+// WithWalkInfoPooling enable reuse of WalkInfo objects, see Walker.WalkInfoPooling
+func (w *Walker) WithWalkInfoPooling(val bool) *Walker {
+	w.WalkInfoPooling = val
+	return w
+}
+
+// WithMaxCallbackErrors set limit of collected errors, see Walker.MaxCallbackErrors
+func (w *Walker) WithMaxCallbackErrors(n int) *Walker {
+	w.MaxCallbackErrors = n
+	return w
+}
+
+// WithWalkFuncSignature enable visit types of func parameters and results, see Walker.WalkFuncSignature
+func (w *Walker) WithWalkFuncSignature(val bool) *Walker {
+	w.WalkFuncSignature = val
+	return w
+}
+
+// WithCanonicalizeFunc set func of value keys for skip equal values, see Walker.CanonicalizeFunc
+func (w *Walker) WithCanonicalizeFunc(f func(info *WalkInfo) (key interface{}, ok bool)) *Walker {
+	w.CanonicalizeFunc = f
+	return w
+}
+
+// WithOnLoopSkip set observer of values, skipped by loop protection, see Walker.OnLoopSkip
+func (w *Walker) WithOnLoopSkip(f func(info *WalkInfo)) *Walker {
+	w.OnLoopSkip = f
+	return w
+}
+
+// WithSliceStreaming enable detach WalkInfo of slice items after walk over them, see Walker.SliceStreaming
+func (w *Walker) WithSliceStreaming(val bool) *Walker {
+	w.SliceStreaming = val
+	return w
+}
+
+// WithMapKeyTypeFilter set filter of map key types, see Walker.MapKeyTypeFilter
+func (w *Walker) WithMapKeyTypeFilter(filter func(keyType reflect.Type) bool) *Walker {
+	w.MapKeyTypeFilter = filter
+	return w
+}
+
+// WithWalkStringRunes enable walk over runes of strings, see Walker.WalkStringRunes
+func (w *Walker) WithWalkStringRunes(val bool) *Walker {
+	w.WalkStringRunes = val
+	return w
+}
+
+// WithTypeRegistry set registry of allowed dynamic types of interfaces, see Walker.TypeRegistry
+func (w *Walker) WithTypeRegistry(registry *TypeRegistry) *Walker {
+	w.TypeRegistry = registry
+	return w
+}
+
+// WithExposeMapInternals enable send runtime map headers to callback, see Walker.ExposeMapInternals
+func (w *Walker) WithExposeMapInternals(val bool) *Walker {
+	w.ExposeMapInternals = val
+	return w
+}
+
+// WithSkipChanDir set direction of skipped channels, see Walker.SkipChanDir
+func (w *Walker) WithSkipChanDir(dir reflect.ChanDir) *Walker {
+	w.SkipChanDir = dir
+	return w
+}
+
+// WithKeepPredicate set predicate of kept branches, see Walker.KeepPredicate
+func (w *Walker) WithKeepPredicate(f func(info *WalkInfo) bool) *Walker {
+	w.KeepPredicate = f
+	return w
+}
+
+// WithSkipKinds set kinds of skipped values, see Walker.SkipKinds
+func (w *Walker) WithSkipKinds(kinds ...reflect.Kind) *Walker {
+	w.SkipKinds = kinds
+	return w
+}
+
+// WithFieldRewriter set rewriter of struct fields, see Walker.FieldRewriter
+func (w *Walker) WithFieldRewriter(f func(info *WalkInfo) (reflect.Value, bool)) *Walker {
+	w.FieldRewriter = f
+	return w
+}
+
+// WithMaxDepth set max depth of walk, see Walker.MaxDepth
+func (w *Walker) WithMaxDepth(depth int) *Walker {
+	w.MaxDepth = depth
+	return w
+}
+
+// WithReadOnly enable protection from change values by WalkInfo setters, see Walker.ReadOnly
+func (w *Walker) WithReadOnly(val bool) *Walker {
+	w.ReadOnly = val
+	return w
+}
+
+// WithCollapsePointerChains enable collapse chains of pointers and interfaces, see Walker.CollapsePointerChains
+func (w *Walker) WithCollapsePointerChains(val bool) *Walker {
+	w.CollapsePointerChains = val
+	return w
+}
+
+// WithTimeout set max duration of walk, see Walker.Timeout
+func (w *Walker) WithTimeout(d time.Duration) *Walker {
+	w.Timeout = d
+	return w
+}
+
+// WithWalkMethods enable send methods of structs and pointers to callback, see Walker.WalkMethods
+func (w *Walker) WithWalkMethods(val bool) *Walker {
+	w.WalkMethods = val
+	return w
+}
+
+// WithSkipValue set sentinel value for skip equal leaves, see Walker.SkipValue
+func (w *Walker) WithSkipValue(v interface{}) *Walker {
+	w.SkipValue = v
+	return w
+}
+
 type walkerState struct {
 	Walker
 	visited map[unsafe.Pointer]map[reflect.Type]empty
+	// hashVisited contains hashes of unaddressable values on path from root to current value
+	hashVisited map[uint64]empty
+	walkedBytes int
+	errs        []error
+	// collectedErrs is count of collected callback errors
+	collectedErrs int
+	stats         WalkStats
+	calledTypes   map[reflect.Type]empty
+	leafBatch     []*WalkInfo
+	canonicalKeys map[interface{}]empty
+	// fieldOrders is cache of fieldsOrder results by struct type
+	fieldOrders map[reflect.Type][]int
+	// keepBranches is result of look ahead for Walker.KeepPredicate, nil before first look ahead
+	keepBranches *keepBranches
+	// deadline is end time of walk by Walker.Timeout, zero if unlimited
+	deadline time.Time
+	// ctx is context of WalkContext, nil for other walks
+	ctx context.Context
 
 	//nolint:unused,structcheck
 	_denyCopyByValue sync.Mutex // error in go vet if try to copy walkerState by value
 }
 
 func newWalkerState(opts Walker) *walkerState {
+	visitedCapacity := opts.VisitedCapacity
+	if visitedCapacity < 0 {
+		visitedCapacity = 0
+	}
 	return &walkerState{
 		Walker:           opts,
-		visited:          make(map[unsafe.Pointer]map[reflect.Type]empty),
+		visited:          make(map[unsafe.Pointer]map[reflect.Type]empty, visitedCapacity),
+		hashVisited:      make(map[uint64]empty),
+		walkedBytes:      0,
+		errs:             nil,
+		collectedErrs:    0,
+		stats:            newWalkStats(opts),
+		calledTypes:      make(map[reflect.Type]empty),
+		leafBatch:        nil,
+		canonicalKeys:    make(map[interface{}]empty),
+		fieldOrders:      nil,
+		keepBranches:     nil,
+		deadline:         walkDeadline(opts.Timeout),
+		ctx:              nil,
 		_denyCopyByValue: sync.Mutex{},
 	}
 }
 
+var walkInfoPool = sync.Pool{
+	New: func() interface{} {
+		return &WalkInfo{}
+	},
+}
+
 func (w *Walker) newWalkerInfo(v reflect.Value, parent *WalkInfo) *WalkInfo {
-	var res WalkInfo
+	var res *WalkInfo
+	if w.WalkInfoPooling {
+		res = walkInfoPool.Get().(*WalkInfo)
+		*res = WalkInfo{}
+	} else {
+		res = &WalkInfo{}
+	}
 	if v.CanAddr() {
 		res.DirectPointer = w.getDirectPointer(&v)
+		if w.ForceInterfaceable && !v.CanInterface() {
+			v = reflect.NewAt(v.Type(), res.DirectPointer).Elem()
+		}
 	}
 	res.Value = v
 	res.Parent = parent
-	return &res
+	if parent != nil {
+		res.depth = parent.depth + 1
+		res.logicalDepth = parent.logicalDepth
+		if kind := parent.Value.Kind(); kind != reflect.Pointer && kind != reflect.Interface {
+			res.logicalDepth++
+		}
+		res.keepMatched = parent.keepMatched
+		if w.ParentChainLimit > 0 && res.depth%w.ParentChainLimit == 0 {
+			res.Parent = parent.limitedChain(w.ParentChainLimit)
+		}
+	}
+	res.Index = -1
+	res.SiblingIndex = -1
+	res.SiblingCount = -1
+	res.Cap = -1
+	res.MapLen = -1
+	res.ArrayLen = -1
+	if v.Kind() == reflect.Chan {
+		res.ChanDir = v.Type().ChanDir()
+	}
+	res.keyFormatter = w.KeyFormatter
+	res.readOnly = w.ReadOnly
+	return res
+}
+
+// limitedChain return copy of w and its ancestors up to nearest ancestor with depth, divisible by limit,
+// for use as Parent of w children with depth, divisible by limit.
+// Chain of every value contains values of its block of limit depths, which linked to copies of previous block,
+// so it has from limit to 2*limit-1 values. Copies of ancestors shared between chains: every value copied once.
+// WalkInfo of ancestors doesn't changed, so every value see same chain, independent of walk order.
+func (w *WalkInfo) limitedChain(limit int) *WalkInfo {
+	if w.limitedChainCache != nil {
+		return w.limitedChainCache
+	}
+
+	head := *w
+	head.root = nil
+	head.limitedChainCache = nil
+	if w.depth%limit == 0 || w.Parent == nil {
+		head.Parent = nil
+	} else {
+		head.Parent = w.Parent.limitedChain(limit)
+	}
+
+	w.limitedChainCache = &head
+	return w.limitedChainCache
 }
 
 func (w *Walker) getDirectPointer(v *reflect.Value) (res unsafe.Pointer) {
@@ -151,47 +1296,316 @@ func (w *Walker) getDirectPointer(v *reflect.Value) (res unsafe.Pointer) {
 	default:
 		return res
 	}
-}
+}
+
+func (state *walkerState) walk(v interface{}, checkValueResult bool) error {
+	if state.UnsafeReadDirectPtr && !checkValueResult {
+		return ErrBadInternalReflectValueDetected
+	}
+
+	rv, ok, err := state.rootValue(v)
+	if err != nil || !ok {
+		return err
+	}
+	return state.walkRoot(rv)
+}
+
+// rootValue return value for start walk from v and false if nothing to walk
+func (state *walkerState) rootValue(v interface{}) (reflect.Value, bool, error) {
+	if v == nil {
+		return reflect.Value{}, false, nil
+	}
+	if rv, ok := v.(reflect.Value); ok && !rv.IsValid() {
+		return reflect.Value{}, false, nil
+	}
+
+	if state.Snapshot {
+		snapshot, err := DeepCopy(v)
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+		v = snapshot
+	}
+	return reflect.ValueOf(v), true, nil
+}
+
+func (state *walkerState) walkRoot(v reflect.Value) error {
+	return state.walkRootInfo(state.newWalkerInfo(v, nil))
+}
+
+// walkRootInfo walk from info as start point of walk and return all walk errors
+func (state *walkerState) walkRootInfo(info *WalkInfo) error {
+	return state.finishWalk(state.walkValue(info))
+}
+
+// finishWalk flush leaf batch and return all walk errors, err is result of walk over roots
+func (state *walkerState) finishWalk(err error) error {
+	if errors.Is(err, ErrStop) {
+		err = nil
+	}
+	if err == nil {
+		err = state.handleCallbackError(nil, state.flushLeafBatch())
+		if errors.Is(err, ErrSkip) || errors.Is(err, ErrStop) {
+			err = nil
+		}
+	}
+	if len(state.errs) > 0 {
+		if err != nil {
+			state.errs = append(state.errs, err)
+		}
+		return errors.Join(state.errs...)
+	}
+	return err
+}
+
+func (state *walkerState) loopDetector(info *WalkInfo) {
+	if state.stats.LoopProtectionDisabled {
+		return
+	}
+
+	if info.DirectPointer != zeroPointer {
+		types := state.visited[info.DirectPointer]
+		if types == nil {
+			if !state.reserveVisitedEntry() {
+				return
+			}
+			types = make(map[reflect.Type]empty)
+			state.visited[info.DirectPointer] = types
+		}
+
+		t := info.Value.Type()
+		_, okType := types[t]
+		if okType {
+			info.IsVisited = true
+			info.ClosesCycleWith = findCycleAncestor(info)
+		} else {
+			types[t] = empty{}
+		}
+		return
+	}
+
+	if state.ValueHashLoopProtection && isComposite(info.Value.Kind()) {
+		if hash, ok := valueHash(info.Value); ok {
+			if _, visited := state.hashVisited[hash]; visited {
+				info.IsVisited = true
+				info.ClosesCycleWith = findHashCycleAncestor(info, hash)
+			} else if state.reserveVisitedEntry() {
+				state.hashVisited[hash] = empty{}
+				info.pathHash = hash
+				info.hasPathHash = true
+			}
+		}
+	}
+}
+
+// canonicalDetector mark value as visited if CanonicalizeFunc return same key for other value before
+func (state *walkerState) canonicalDetector(info *WalkInfo) {
+	key, ok := state.CanonicalizeFunc(info)
+	if !ok {
+		return
+	}
+	if _, seen := state.canonicalKeys[key]; seen {
+		info.IsVisited = true
+		return
+	}
+	state.canonicalKeys[key] = empty{}
+}
+
+// findCycleAncestor return ancestor of info with same address and type or nil
+func findCycleAncestor(info *WalkInfo) *WalkInfo {
+	t := info.Value.Type()
+	for parent := info.Parent; parent != nil; parent = parent.Parent {
+		if parent.DirectPointer == info.DirectPointer && parent.Value.Type() == t {
+			return parent
+		}
+	}
+	return nil
+}
+
+// findHashCycleAncestor return ancestor of info with same content hash, see Walker.ValueHashLoopProtection
+func findHashCycleAncestor(info *WalkInfo, hash uint64) *WalkInfo {
+	for parent := info.Parent; parent != nil; parent = parent.Parent {
+		if parent.hasPathHash && parent.pathHash == hash {
+			return parent
+		}
+	}
+	return nil
+}
+
+// reserveVisitedEntry check limit of visited entries before remember new value.
+// It disable loop protection and return false if limit exceeded.
+func (state *walkerState) reserveVisitedEntry() bool {
+	if state.VisitedMaxEntries <= 0 || len(state.visited)+len(state.hashVisited) < state.VisitedMaxEntries {
+		return true
+	}
+	state.stats.LoopProtectionDisabled = true
+	state.visited = nil
+	state.hashVisited = nil
+	return false
+}
+
+// isEqualLeaf return true if info value is leaf and deep equal to v
+func isEqualLeaf(info *WalkInfo, v interface{}) bool {
+	if isComposite(info.Value.Kind()) || info.Value.Type() != reflect.TypeOf(v) {
+		return false
+	}
+	value := info.Value
+	if !value.CanInterface() {
+		if !info.HasDirectPointer() {
+			return false
+		}
+		value = reflect.NewAt(value.Type(), info.DirectPointer).Elem()
+	}
+	return reflect.DeepEqual(value.Interface(), v)
+}
+
+func isComposite(kind reflect.Kind) bool {
+	//nolint:exhaustive
+	switch kind {
+	case reflect.Array, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.Struct:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasDepthChildren return true if v is not empty array, map, slice or struct.
+// Pointers and interfaces doesn't checked, because they doesn't increase depth of their elements, see Walker.MaxDepth.
+func hasDepthChildren(v reflect.Value) bool {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice:
+		return v.Len() > 0
+	case reflect.Struct:
+		return v.NumField() > 0
+	default:
+		return false
+	}
+}
+
+// walkValue walk over info value and children of it
+func (state *walkerState) walkValue(info *WalkInfo) error {
+	err := state.walkValueNode(info)
+	if info.hasPathHash {
+		delete(state.hashVisited, info.pathHash)
+	}
+	if state.isPoolingEnabled() {
+		*info = WalkInfo{}
+		walkInfoPool.Put(info)
+	}
+	return err
+}
+
+func (state *walkerState) isPoolingEnabled() bool {
+	return state.WalkInfoPooling && state.LeafBatchFunc == nil
+}
+
+func (state *walkerState) walkValueNode(info *WalkInfo) error {
+	if !info.Value.IsValid() {
+		return nil
+	}
+
+	state.loopDetector(info)
+	if state.CanonicalizeFunc != nil && !info.IsVisited {
+		state.canonicalDetector(info)
+	}
+	if info.IsVisited && state.LoopProtection {
+		if state.OnLoopSkip != nil {
+			state.OnLoopSkip(info)
+		}
+		return nil
+	}
+
+	if state.SkipFunc != nil && state.SkipFunc(info) {
+		return nil
+	}
+
+	if state.SkipChanDir != 0 && info.ChanDir == state.SkipChanDir {
+		return nil
+	}
+
+	if len(state.SkipKinds) > 0 && containsKind(state.SkipKinds, info.Value.Kind()) {
+		return nil
+	}
+
+	if state.SkipValue != nil && isEqualLeaf(info, state.SkipValue) {
+		return nil
+	}
+
+	if len(state.StrictKinds) > 0 && !state.isAllowedKind(info.Value.Kind()) {
+		return fmt.Errorf("value of kind %v at path %q: %w", info.Value.Kind(), info.Path(), ErrKindNotAllowed)
+	}
+
+	if state.MaxBytes > 0 {
+		state.walkedBytes = addSizes(state.walkedBytes, valueSize(info.Value))
+		if state.walkedBytes > state.MaxBytes {
+			return ErrByteBudgetExceeded
+		}
+	}
+
+	if state.StringInterning && info.Value.Kind() == reflect.String {
+		state.stats.StringCounts[info.Value.String()]++
+	}
+
+	if state.MaxDepth > 0 && info.logicalDepth >= state.MaxDepth && hasDepthChildren(info.Value) {
+		info.Truncated = true
+		info.skipChildren = true
+	}
+
+	if state.MathBigAsLeaf && isMathBig(info.Value.Type()) {
+		return state.walkSimple(info)
+	}
+
+	if len(state.AllowedPackages) > 0 && !state.isAllowedPackage(info.Value.Type().PkgPath()) {
+		return state.walkSimple(info)
+	}
+
+	if state.FollowReflectValue && info.Value.Type() == reflectValueType {
+		if wrapped, ok := wrappedReflectValue(info); ok {
+			return state.walkReflectValue(info, wrapped)
+		}
+	}
 
-func (state *walkerState) walk(v interface{}, checkValueResult bool) error {
-	if state.UnsafeReadDirectPtr && !checkValueResult {
-		return ErrBadInternalReflectValueDetected
+	if state.UnwrapErrors {
+		if getChildren, ok := asErrorUnwrapper(info.Value); ok {
+			return state.walkLogicalChildren(info, getChildren)
+		}
 	}
 
-	if v == nil {
-		return nil
+	if state.RespectWalkable {
+		if walkable, ok := asWalkable(info.Value); ok {
+			return state.walkWalkable(info, walkable)
+		}
 	}
 
-	valueInfo := state.newWalkerInfo(reflect.ValueOf(v), nil)
-	return state.walkValue(valueInfo)
+	return state.kindRoute(info.Value.Kind(), info)
 }
 
-func (state *walkerState) loopDetector(info *WalkInfo) {
-	if info.DirectPointer != zeroPointer {
-		types := state.visited[info.DirectPointer]
-		if types == nil {
-			types = make(map[reflect.Type]empty)
-			state.visited[info.DirectPointer] = types
-		}
+func (state *walkerState) isAllowedKind(kind reflect.Kind) bool {
+	return containsKind(state.StrictKinds, kind)
+}
 
-		t := info.Value.Type()
-		_, okType := types[t]
-		if okType {
-			info.IsVisited = true
-		} else {
-			types[t] = empty{}
+// containsKind return true if kinds contains kind
+func containsKind(kinds []reflect.Kind, kind reflect.Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
 		}
-
 	}
+	return false
 }
 
-func (state *walkerState) walkValue(info *WalkInfo) error {
-	state.loopDetector(info)
-	if info.IsVisited && state.LoopProtection {
-		return nil
+func (state *walkerState) isAllowedPackage(pkgPath string) bool {
+	if pkgPath == "" {
+		return true
 	}
-
-	return state.kindRoute(info.Value.Kind(), info)
+	for _, prefix := range state.AllowedPackages {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+			return true
+		}
+	}
+	return false
 }
 
 func (state *walkerState) kindRoute(kind reflect.Kind, info *WalkInfo) error {
@@ -200,29 +1614,239 @@ func (state *walkerState) kindRoute(kind reflect.Kind, info *WalkInfo) error {
 		return errInvalidKind
 	case reflect.Array:
 		return state.walkArray(info)
-	case reflect.Interface, reflect.Ptr:
+	case reflect.Interface, reflect.Pointer:
 		return state.walkPtr(info)
 	case reflect.Map:
 		return state.walkMap(info)
 	case reflect.Slice:
 		return state.walkSlice(info)
-	case reflect.Chan, reflect.Func, reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+	case reflect.Func:
+		return state.walkFunc(info)
+	case reflect.String:
+		return state.walkString(info)
+	case reflect.Chan, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
 		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64,
 		reflect.Complex128, reflect.UnsafePointer:
 		return state.walkSimple(info)
 	case reflect.Struct:
 		return state.walkStruct(info)
 	default:
+		if state.IgnoreUnknownKinds {
+			return state.walkSimple(info)
+		}
 		return fmt.Errorf("can't walk into kind %v value: %w", info.Value.Kind(), ErrUnknownKind)
 	}
 }
 
+// call run callback and hooks for the info
+func (state *walkerState) call(info *WalkInfo) error {
+	if err := state.checkInterrupt(); err != nil {
+		return err
+	}
+	if state.SkipRoot && isRootIndirection(info) {
+		return skipChildrenErr(info)
+	}
+	if state.KeepPredicate != nil && !info.keepMatched {
+		keep, err := state.isKeptBranch(info)
+		if err != nil {
+			return err
+		}
+		if !keep {
+			if info.isMapKey {
+				// ErrSkip for map key skip map value too
+				return nil
+			}
+			return ErrSkip
+		}
+	}
+	if state.OncePerType && !info.isMapEntry {
+		t := info.Value.Type()
+		if _, called := state.calledTypes[t]; called {
+			return skipChildrenErr(info)
+		}
+		state.calledTypes[t] = empty{}
+	}
+	if state.NamedTypeHook != nil && !info.isMapEntry {
+		t := info.Value.Type()
+		if t.Name() != "" && t.PkgPath() != "" {
+			if err := state.protectedCall(state.NamedTypeHook, info); err != nil {
+				return state.handleCallbackError(info, err)
+			}
+		}
+	}
+	if state.LeafBatchFunc != nil && !isComposite(info.Value.Kind()) && !info.isMapEntry {
+		return state.handleCallbackError(nil, state.addToLeafBatch(info))
+	}
+	if err := state.runCallback(info); err != nil {
+		return state.handleCallbackError(info, err)
+	}
+	if state.StopOnType != nil && !info.isMapEntry && info.Value.Type() == state.StopOnType {
+		return ErrStop
+	}
+	return skipChildrenErr(info)
+}
+
+// skipChildrenErr return ErrSkip if children of composite value must be skipped,
+// for example by WalkInfo.SkipChildren or Walker.MaxDepth, even if callback wasn't called for the value
+func skipChildrenErr(info *WalkInfo) error {
+	if info.skipChildren && isComposite(info.Value.Kind()) {
+		return ErrSkip
+	}
+	return nil
+}
+
+// addToLeafBatch add leaf to batch and send the batch to LeafBatchFunc if batch is full
+func (state *walkerState) addToLeafBatch(info *WalkInfo) error {
+	state.leafBatch = append(state.leafBatch, info)
+	if len(state.leafBatch) < state.LeafBatchSize {
+		return nil
+	}
+	return state.flushLeafBatch()
+}
+
+// flushLeafBatch send collected leaves to LeafBatchFunc
+func (state *walkerState) flushLeafBatch() error {
+	if len(state.leafBatch) == 0 {
+		return nil
+	}
+	batch := state.leafBatch
+	state.leafBatch = nil
+	return state.LeafBatchFunc(batch)
+}
+
+// runCallback call callback and measure time of the call if profiling enabled
+func (state *walkerState) runCallback(info *WalkInfo) error {
+	if !state.Profiling {
+		return state.protectedCall(state.callback, info)
+	}
+
+	start := time.Now()
+	err := state.protectedCall(state.callback, info)
+	state.stats.CallbackDurationByKind[info.Value.Kind()] += time.Since(start)
+	return err
+}
+
+// protectedCall call f and convert its panic to error if panics recover enabled
+func (state *walkerState) protectedCall(f WalkFunc, info *WalkInfo) (err error) {
+	if state.RecoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				if panicErr, ok := r.(error); ok {
+					err = fmt.Errorf("%w at path %q: %w", ErrCallbackPanic, info.Path(), panicErr)
+				} else {
+					err = fmt.Errorf("%w at path %q: %v", ErrCallbackPanic, info.Path(), r)
+				}
+			}
+		}()
+	}
+	return f(info)
+}
+
+// isRootIndirection return true for root value and values, pointed by root directly or through other pointers
+func isRootIndirection(info *WalkInfo) bool {
+	last := info
+	for parent := info.Parent; parent != nil; parent = parent.Parent {
+		if kind := parent.Value.Kind(); kind != reflect.Pointer && kind != reflect.Interface {
+			return false
+		}
+		last = parent
+	}
+	return last.depth == 0
+}
+
+// handleCallbackError send error to OnError, collect it if need and replace it by errCollected
+func (state *walkerState) handleCallbackError(info *WalkInfo, err error) error {
+	if err == nil || errors.Is(err, ErrSkip) || errors.Is(err, ErrStop) {
+		return err
+	}
+	if state.OnError != nil {
+		state.OnError(info, err)
+	}
+	if !state.CollectErrors {
+		return err
+	}
+	if state.MaxCallbackErrors > 0 && state.collectedErrs >= state.MaxCallbackErrors {
+		if state.collectedErrs == state.MaxCallbackErrors {
+			state.errs = append(state.errs, ErrCallbackErrorsTruncated)
+			state.collectedErrs++
+		}
+		return errCollected
+	}
+	state.collectedErrs++
+	state.errs = append(state.errs, err)
+	return errCollected
+}
+
 func (state *walkerState) walkSimple(info *WalkInfo) error {
-	return state.callback(info)
+	err := state.call(info)
+	if errors.Is(err, ErrSkip) && !info.isMapKey {
+		return nil
+	}
+	return err
+}
+
+func (state *walkerState) walkString(info *WalkInfo) error {
+	if !state.WalkStringRunes {
+		return state.walkSimple(info)
+	}
+
+	if err := state.call(info); err != nil {
+		if errors.Is(err, ErrSkip) && !info.isMapKey {
+			return nil
+		}
+		return err
+	}
+
+	runes := []rune(info.Value.String())
+	for i, r := range runes {
+		runeInfo := state.newWalkerInfo(reflect.ValueOf(r), info)
+		runeInfo.IsStringRune = true
+		runeInfo.Index = i
+		runeInfo.SiblingIndex = i
+		runeInfo.SiblingCount = len(runes)
+		if err := skipToNil(state.call(runeInfo)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (state *walkerState) walkFunc(info *WalkInfo) error {
+	if !state.WalkFuncSignature {
+		return state.walkSimple(info)
+	}
+
+	if err := state.call(info); err != nil {
+		return skipToNil(err)
+	}
+
+	t := info.Value.Type()
+	for i := 0; i < t.NumIn(); i++ {
+		paramInfo := state.newWalkerInfo(reflect.Zero(t.In(i)), info)
+		paramInfo.IsFuncParam = true
+		paramInfo.SiblingIndex = i
+		paramInfo.SiblingCount = t.NumIn()
+		if err := skipToNil(state.call(paramInfo)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < t.NumOut(); i++ {
+		resultInfo := state.newWalkerInfo(reflect.Zero(t.Out(i)), info)
+		resultInfo.IsFuncResult = true
+		resultInfo.SiblingIndex = i
+		resultInfo.SiblingCount = t.NumOut()
+		if err := skipToNil(state.call(resultInfo)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (state *walkerState) walkArray(info *WalkInfo) error {
-	if err := state.callback(info); err != nil {
+	info.Cap = info.Value.Cap()
+	info.ArrayLen = info.Value.Type().Len()
+	updateMax(&state.stats.MaxArrayLen, info.ArrayLen)
+	if err := state.call(info); err != nil {
 		if errors.Is(err, ErrSkip) {
 			return nil
 		}
@@ -230,9 +1854,16 @@ func (state *walkerState) walkArray(info *WalkInfo) error {
 	}
 
 	vLen := info.Value.Len()
+	if vLen == 0 {
+		return state.probeEmptyCollection(info, info.Value.Type().Elem())
+	}
 	for i := 0; i < vLen; i++ {
-		item := info.Value.Index(i)
+		index := state.itemIndex(vLen, i)
+		item := info.Value.Index(index)
 		itemInfo := state.newWalkerInfo(item, info)
+		itemInfo.Index = index
+		itemInfo.SiblingIndex = index
+		itemInfo.SiblingCount = vLen
 		if err := state.walkValue(itemInfo); err != nil {
 			return err
 		}
@@ -240,57 +1871,257 @@ func (state *walkerState) walkArray(info *WalkInfo) error {
 	return nil
 }
 
+// probeEmptyCollection call callback for zero values of types as children of info if probes enabled
+func (state *walkerState) probeEmptyCollection(info *WalkInfo, types ...reflect.Type) error {
+	if !state.EmptyCollectionProbe {
+		return nil
+	}
+	for _, t := range types {
+		probeInfo := state.newWalkerInfo(reflect.Zero(t), info)
+		probeInfo.IsProbe = true
+		if err := skipToNil(state.call(probeInfo)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// itemIndex return index of i-th visited item of slice or array with length n
+func (state *walkerState) itemIndex(n, i int) int {
+	if state.ReverseSliceOrder {
+		return n - 1 - i
+	}
+	return i
+}
+
 func (state *walkerState) walkPtr(info *WalkInfo) error {
-	if err := state.callback(info); err != nil {
+	if state.CollapsePointerChains {
+		return state.walkPointerChain(info)
+	}
+
+	if state.TransparentPointers && info.Value.Kind() == reflect.Pointer {
+		if info.Value.IsNil() {
+			return nil
+		}
+		return state.walkValue(state.newPositionInfo(info.Value.Elem(), info))
+	}
+
+	if err := state.call(info); err != nil {
 		if errors.Is(err, ErrSkip) {
 			return nil
 		}
 		return err
 	}
+	if state.WalkMethods && info.Value.Kind() == reflect.Pointer {
+		if err := state.walkMethods(info); err != nil {
+			return err
+		}
+	}
 	if info.Value.IsNil() {
 		return nil
 	}
 	elem := info.Value.Elem()
+	if info.Value.Kind() == reflect.Interface {
+		var err error
+		if elem, err = state.interfaceElem(info); err != nil || !elem.IsValid() {
+			return skipToNil(err)
+		}
+	}
 	return state.walkValue(state.newWalkerInfo(elem, info))
 }
 
+// interfaceElem return element of not nil interface info after Walker.InterfaceTypeCallback, Walker.InterfaceResolver
+// and Walker.TypeRegistry checks. Invalid value mean no element.
+func (state *walkerState) interfaceElem(info *WalkInfo) (reflect.Value, error) {
+	elem := info.Value.Elem()
+	if state.InterfaceTypeCallback != nil {
+		if err := state.handleCallbackError(info, state.InterfaceTypeCallback(info, elem.Type())); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	if state.InterfaceResolver != nil {
+		if resolved, ok := state.InterfaceResolver(info); ok {
+			elem = resolved
+		}
+	}
+	if state.TypeRegistry != nil && elem.IsValid() {
+		if _, ok := state.TypeRegistry.NameOf(elem.Type()); !ok {
+			return reflect.Value{}, fmt.Errorf("dynamic type %v at path %q: %w", elem.Type(), info.Path(), ErrUnregisteredType)
+		}
+	}
+	return elem, nil
+}
+
+// newPositionInfo create info for v, which placed at position of info: same parent, struct field, index and map key
+func (state *walkerState) newPositionInfo(v reflect.Value, info *WalkInfo) *WalkInfo {
+	res := state.newWalkerInfo(v, info.Parent)
+	res.StructField = info.StructField
+	res.Index = info.Index
+	res.SiblingIndex = info.SiblingIndex
+	res.SiblingCount = info.SiblingCount
+	res.isMapKey = info.isMapKey
+	res.isMapValue = info.isMapValue
+	res.mapKey = info.mapKey
+	return res
+}
+
+// walkPointerChain walk over first not pointer value of chain of pointers and interfaces, started from info
+func (state *walkerState) walkPointerChain(info *WalkInfo) error {
+	v := info.Value
+	levels := 0
+	var seen []uintptr
+	for kind := v.Kind(); (kind == reflect.Pointer || kind == reflect.Interface) && !v.IsNil(); kind = v.Kind() {
+		if kind == reflect.Pointer {
+			// pointer loop, for example interface, which contains pointer to itself
+			ptr := v.Pointer()
+			for _, seenPtr := range seen {
+				if seenPtr == ptr {
+					return nil
+				}
+			}
+			seen = append(seen, ptr)
+			v = v.Elem()
+		} else {
+			hopInfo := info
+			if levels > 0 {
+				hopInfo = state.newPositionInfo(v, info)
+				hopInfo.PointerDepth = levels
+			}
+			var err error
+			if v, err = state.interfaceElem(hopInfo); err != nil || !v.IsValid() {
+				return skipToNil(err)
+			}
+		}
+		levels++
+	}
+
+	if levels == 0 {
+		// nil pointer or interface
+		if state.TransparentPointers && v.Kind() == reflect.Pointer {
+			return nil
+		}
+		return state.walkSimple(info)
+	}
+
+	elemInfo := state.newPositionInfo(v, info)
+	elemInfo.PointerDepth = levels
+	return state.walkValue(elemInfo)
+}
+
 func (state *walkerState) walkMap(info *WalkInfo) error {
-	if err := state.callback(info); err != nil {
+	info.MapLen = info.Value.Len()
+	updateMax(&state.stats.MaxMapLen, info.MapLen)
+	if err := state.call(info); err != nil {
 		if errors.Is(err, ErrSkip) {
 			return nil
 		}
 		return err
 	}
 
-	if info.Value.IsNil() {
+	if state.ExposeMapInternals {
+		if header := info.UnsafeHeader(); header != nil {
+			internalsInfo := state.newWalkerInfo(reflect.ValueOf(newMapInternals(header)), info)
+			internalsInfo.IsMapInternals = true
+			if err := skipToNil(state.call(internalsInfo)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if state.MapKeyTypeFilter != nil && !state.MapKeyTypeFilter(info.Value.Type().Key()) {
 		return nil
 	}
 
-	iterator := info.Value.MapRange()
-	for iterator.Next() {
-		key := iterator.Key()
-		keyInfo := state.newWalkerInfo(key, info)
-		keyInfo.isMapKey = true
+	if info.Value.Len() == 0 {
+		return state.probeEmptyCollection(info, info.Value.Type().Key(), info.Value.Type().Elem())
+	}
 
-		if err := state.walkValue(keyInfo); err != nil {
-			if errors.Is(err, ErrSkip) {
-				continue
+	if state.MapIterationSnapshot {
+		entries := make([]mapEntry, 0, info.Value.Len())
+		iterator := info.Value.MapRange()
+		for iterator.Next() {
+			entries = append(entries, mapEntry{key: iterator.Key(), value: iterator.Value()})
+		}
+		for _, entry := range entries {
+			if err := state.walkMapEntry(info, entry.key, entry.value); err != nil {
+				return err
 			}
-			return err
 		}
+		return nil
+	}
 
-		val := iterator.Value()
-		valInfo := state.newWalkerInfo(val, info)
-		valInfo.isMapValue = true
-		if err := state.walkValue(valInfo); err != nil {
+	iterator := info.Value.MapRange()
+	for iterator.Next() {
+		if err := state.walkMapEntry(info, iterator.Key(), iterator.Value()); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+type mapEntry struct {
+	key   reflect.Value
+	value reflect.Value
+}
+
+func (state *walkerState) walkMapEntry(mapInfo *WalkInfo, key, val reflect.Value) error {
+	if state.MapEntryMode {
+		entryInfo := state.newWalkerInfo(val, mapInfo)
+		entryInfo.isMapEntry = true
+		entryInfo.mapKey = key
+		entryInfo.EntryKey = key
+		entryInfo.EntryValue = val
+		if err := state.call(entryInfo); err != nil {
+			return skipToNil(err)
+		}
+	}
+
+	switch state.MapKeyVisit {
+	case MapValueOnly:
+		return state.walkMapValue(mapInfo, key, val)
+	case MapKeyOnly:
+		return skipToNil(state.walkMapKey(mapInfo, key))
+	case MapValueThenKey:
+		if err := state.walkMapValue(mapInfo, key, val); err != nil {
+			return err
+		}
+		return skipToNil(state.walkMapKey(mapInfo, key))
+	case MapKeyThenValue:
+		fallthrough
+	default:
+		if err := state.walkMapKey(mapInfo, key); err != nil {
+			return skipToNil(err)
+		}
+		return state.walkMapValue(mapInfo, key, val)
+	}
+}
+
+func (state *walkerState) walkMapKey(mapInfo *WalkInfo, key reflect.Value) error {
+	keyInfo := state.newWalkerInfo(key, mapInfo)
+	keyInfo.isMapKey = true
+	keyInfo.mapKey = key
+	return state.walkValue(keyInfo)
+}
+
+func (state *walkerState) walkMapValue(mapInfo *WalkInfo, key, val reflect.Value) error {
+	valInfo := state.newWalkerInfo(val, mapInfo)
+	valInfo.isMapValue = true
+	valInfo.mapKey = key
+	return state.walkValue(valInfo)
+}
+
+// skipToNil return nil for ErrSkip and err for other errors
+func skipToNil(err error) error {
+	if errors.Is(err, ErrSkip) {
+		return nil
+	}
+	return err
+}
+
 func (state *walkerState) walkSlice(info *WalkInfo) error {
-	if err := state.callback(info); err != nil {
+	info.Cap = info.Value.Cap()
+	updateMax(&state.stats.MaxSliceLen, info.Value.Len())
+	if err := state.call(info); err != nil {
 		if errors.Is(err, ErrSkip) {
 			return nil
 		}
@@ -298,9 +2129,25 @@ func (state *walkerState) walkSlice(info *WalkInfo) error {
 	}
 
 	sliceLen := info.Value.Len()
+	if sliceLen == 0 {
+		return state.probeEmptyCollection(info, info.Value.Type().Elem())
+	}
 	for i := 0; i < sliceLen; i++ {
-		item := info.Value.Index(i)
-		if err := state.walkValue(state.newWalkerInfo(item, info)); err != nil {
+		index := state.itemIndex(sliceLen, i)
+		itemInfo := state.newWalkerInfo(info.Value.Index(index), info)
+		itemInfo.Index = index
+		itemInfo.SiblingIndex = index
+		itemInfo.SiblingCount = sliceLen
+		err := state.walkValue(itemInfo)
+		if state.SliceStreaming && !state.WalkInfoPooling && state.LeafBatchFunc == nil {
+			// pooled info can be used by other walker already, batched info will be used after walk over item
+			itemInfo.Value = reflect.Value{}
+			itemInfo.DirectPointer = nil
+			itemInfo.Parent = nil
+			itemInfo.root = nil
+			itemInfo.mapKey = reflect.Value{}
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -309,17 +2156,82 @@ func (state *walkerState) walkSlice(info *WalkInfo) error {
 }
 
 func (state *walkerState) walkStruct(info *WalkInfo) error {
-	if err := state.callback(info); err != nil {
+	if err := state.call(info); err != nil {
 		if errors.Is(err, ErrSkip) {
 			return nil
 		}
 		return err
 	}
 
-	numField := info.Value.NumField()
-	for i := 0; i < numField; i++ {
-		fieldVal := info.Value.Field(i)
+	if state.WalkMethods && !isPointerElem(info) {
+		if err := state.walkMethods(info); err != nil {
+			return err
+		}
+	}
+
+	return state.walkStructFields(info, info.Value, false)
+}
+
+// isPointerElem return true if info is element of walked pointer
+func isPointerElem(info *WalkInfo) bool {
+	return info.Parent != nil && info.Parent.Value.Kind() == reflect.Pointer
+}
+
+// walkMethods send exported methods of info value to callback
+func (state *walkerState) walkMethods(info *WalkInfo) error {
+	t := info.Value.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		methodInfo := state.newWalkerInfo(info.Value.Method(i), info)
+		methodInfo.IsMethod = true
+		methodInfo.Method = &method
+		if err := skipToNil(state.call(methodInfo)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkStructFields walk over fields of structValue as children of info,
+// promoted is true for struct of embedded interface, which fields aren't fields of info value
+func (state *walkerState) walkStructFields(info *WalkInfo, structValue reflect.Value, promoted bool) error {
+	structType := structValue.Type()
+	numField := state.allowedFieldsCount(structType)
+	order := state.fieldsOrder(structType)
+	for pos := 0; pos < structType.NumField(); pos++ {
+		i := pos
+		if order != nil {
+			i = order[pos]
+		}
+		field := structType.Field(i)
+		if !state.isFieldAllowed(field) {
+			continue
+		}
+		fieldVal := structValue.Field(i)
+		if state.PromoteEmbeddedInterfaces && field.Anonymous {
+			if promotedValue, ok := embeddedInterfaceStruct(fieldVal); ok {
+				if state.isPromotedVisited(promotedValue) {
+					continue
+				}
+				if err := state.walkStructFields(info, promotedValue, true); err != nil {
+					return err
+				}
+				continue
+			}
+		}
 		fieldInfo := state.newWalkerInfo(fieldVal, info)
+		fieldInfo.StructField = &field
+		if !promoted {
+			fieldInfo.SiblingIndex = i
+			fieldInfo.SiblingCount = numField
+		}
+		if state.FieldRewriter != nil {
+			if newVal, ok := state.FieldRewriter(fieldInfo); ok {
+				if err := fieldInfo.TrySet(newVal); err != nil {
+					return fmt.Errorf("rewrite field at path %q: %w", fieldInfo.Path(), err)
+				}
+			}
+		}
 		if err := state.walkValue(fieldInfo); err != nil {
 			return err
 		}
@@ -327,3 +2239,93 @@ func (state *walkerState) walkStruct(info *WalkInfo) error {
 
 	return nil
 }
+
+// isPromotedVisited mark promoted struct of embedded interface as visited and return true
+// if it was visited before, for example if embedded interface point to owner struct
+func (state *walkerState) isPromotedVisited(promoted reflect.Value) bool {
+	if !promoted.CanAddr() {
+		return false
+	}
+	probe := WalkInfo{Value: promoted, DirectPointer: state.getDirectPointer(&promoted)}
+	state.loopDetector(&probe)
+	return probe.IsVisited && state.LoopProtection
+}
+
+// embeddedInterfaceStruct return struct, stored in interface v directly or by pointer
+func embeddedInterfaceStruct(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() != reflect.Interface || v.IsNil() {
+		return reflect.Value{}, false
+	}
+	elem := v.Elem()
+	if elem.Kind() == reflect.Pointer {
+		if elem.IsNil() {
+			return reflect.Value{}, false
+		}
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	return elem, true
+}
+
+// fieldsOrder return indexes of struct fields in order of visit, nil for declaration order
+func (state *walkerState) fieldsOrder(t reflect.Type) []int {
+	if state.StructFieldOrder != FieldOrderAlphabetical && state.StructFieldOrder != FieldOrderReverse {
+		return nil
+	}
+	if res, ok := state.fieldOrders[t]; ok {
+		return res
+	}
+
+	res := make([]int, t.NumField())
+	names := make([]string, t.NumField())
+	for i := range res {
+		res[i] = i
+		names[i] = t.Field(i).Name
+	}
+
+	//nolint:exhaustive
+	switch state.StructFieldOrder {
+	case FieldOrderAlphabetical:
+		sort.SliceStable(res, func(i, j int) bool {
+			return names[res[i]] < names[res[j]]
+		})
+	case FieldOrderReverse:
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+
+	if state.fieldOrders == nil {
+		state.fieldOrders = make(map[reflect.Type][]int)
+	}
+	state.fieldOrders[t] = res
+	return res
+}
+
+// allowedFieldsCount return count of fields of struct type t, allowed by field tag filter
+func (state *walkerState) allowedFieldsCount(t reflect.Type) int {
+	if state.FieldTagFilterKey == "" {
+		return t.NumField()
+	}
+	res := 0
+	for i := 0; i < t.NumField(); i++ {
+		if state.isFieldAllowed(t.Field(i)) {
+			res++
+		}
+	}
+	return res
+}
+
+// isFieldAllowed check field tag by field tag filter
+func (state *walkerState) isFieldAllowed(field reflect.StructField) bool {
+	if state.FieldTagFilterKey == "" {
+		return true
+	}
+	tagValue, ok := field.Tag.Lookup(state.FieldTagFilterKey)
+	if state.FieldTagFilterValue == "" {
+		return ok
+	}
+	return tagValue == state.FieldTagFilterValue
+}
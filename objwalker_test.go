@@ -14,6 +14,36 @@ import (
 
 var errTest = errors.New("test")
 
+// walkCollect walk over val by w and return results of collect for callback calls, where collect return true
+func walkCollect(t *testing.T, w *Walker, val interface{}, collect func(info *WalkInfo) (string, bool)) []string {
+	t.Helper()
+	var res []string
+	w.callback = func(info *WalkInfo) error {
+		if s, ok := collect(info); ok {
+			res = append(res, s)
+		}
+		return nil
+	}
+	require.NoError(t, w.Walk(val))
+	return res
+}
+
+// walkPaths walk over val by w and return paths of walked values
+func walkPaths(t *testing.T, w *Walker, val interface{}) []string {
+	t.Helper()
+	return walkCollect(t, w, val, func(info *WalkInfo) (string, bool) {
+		return info.Path(), true
+	})
+}
+
+// walkKindPaths walk over val by w and return "path:kind" of walked values
+func walkKindPaths(t *testing.T, w *Walker, val interface{}) []string {
+	t.Helper()
+	return walkCollect(t, w, val, func(info *WalkInfo) (string, bool) {
+		return info.Path() + ":" + info.Value.Kind().String(), true
+	})
+}
+
 func TestWalker_LoopProtected(t *testing.T) {
 	type S struct {
 		P *S
@@ -135,6 +165,93 @@ func TestWalker_Walk(t *testing.T) {
 		require.NoError(t, err)
 		require.False(t, called)
 	})
+
+	t.Run("nil-ish", func(t *testing.T) {
+		var err error
+		var iface interface{}
+		var rv reflect.Value
+		for _, val := range []interface{}{err, iface, rv, reflect.ValueOf(nil)} {
+			called := false
+			walkErr := New(func(info *WalkInfo) error {
+				called = true
+				return nil
+			}).Walk(val)
+			require.NoError(t, walkErr)
+			require.False(t, called)
+		}
+	})
+}
+
+func TestWalker_WalkTransform(t *testing.T) {
+	t.Run("ReplaceRoot", func(t *testing.T) {
+		res, err := New(func(info *WalkInfo) error {
+			return info.TrySet(reflect.ValueOf(2))
+		}).WalkTransform(1)
+		require.NoError(t, err)
+		require.Equal(t, 2, res)
+	})
+
+	t.Run("NotChanged", func(t *testing.T) {
+		res, err := New(func(info *WalkInfo) error {
+			return nil
+		}).WalkTransform(1)
+		require.NoError(t, err)
+		require.Equal(t, 1, res)
+	})
+
+	t.Run("StructByValue", func(t *testing.T) {
+		type S struct {
+			Val int
+		}
+		res, err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				return info.SetInt(3)
+			}
+			return nil
+		}).WalkTransform(S{Val: 1})
+		require.NoError(t, err)
+		require.Equal(t, S{Val: 3}, res)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		res, err := New(func(info *WalkInfo) error {
+			return errTest
+		}).WalkTransform(1)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, 1, res)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		res, err := New(func(info *WalkInfo) error {
+			return errTest
+		}).WalkTransform(nil)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("InvalidReflectValue", func(t *testing.T) {
+		res, err := New(func(info *WalkInfo) error {
+			return errTest
+		}).WalkTransform(reflect.Value{})
+		require.NoError(t, err)
+		require.Equal(t, reflect.Value{}, res)
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		type S struct {
+			Val int
+		}
+		val := &S{Val: 1}
+		res, err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				return info.SetInt(3)
+			}
+			return nil
+		}).WithSnapshot(true).WalkTransform(val)
+		require.NoError(t, err)
+		require.Equal(t, 3, res.(*S).Val)
+		require.Equal(t, 1, val.Val)
+	})
 }
 
 //nolint:gocyclo
@@ -228,6 +345,24 @@ func TestWalker_WalkFlat(t *testing.T) {
 	})
 }
 
+func TestWalker_WalkFlatSkip(t *testing.T) {
+	val := struct {
+		A int
+		B string
+		C int
+	}{A: 1, B: "2", C: 3}
+
+	var visited []interface{}
+	require.NoError(t, New(func(info *WalkInfo) error {
+		visited = append(visited, info.Value.Interface())
+		if info.Value.Kind() != reflect.Struct {
+			return ErrSkip
+		}
+		return nil
+	}).Walk(val))
+	require.Equal(t, []interface{}{val, 1, "2", 3}, visited)
+}
+
 func TestWalker_WalkFunc(t *testing.T) {
 	val := func() int { return 1 }
 	t.Run("Ok", func(t *testing.T) {
@@ -353,7 +488,7 @@ func TestWalker_Ptr(t *testing.T) {
 				wasPtr := false
 				wasInt := false
 				err := New(func(info *WalkInfo) error {
-					if info.Value.Kind() == reflect.Ptr {
+					if info.Value.Kind() == reflect.Pointer {
 						wasPtr = true
 						if testName == "Skip" {
 							return ErrSkip
@@ -405,6 +540,20 @@ func TestWalker_KindRoute(t *testing.T) {
 		require.ErrorIs(t, state.kindRoute(reflect.Invalid, &WalkInfo{}), errInvalidKind)
 		require.ErrorIs(t, state.kindRoute(reflect.Kind(math.MaxUint), &WalkInfo{}), ErrUnknownKind)
 	})
+
+	t.Run("IgnoreUnknownKinds", func(t *testing.T) {
+		var called []interface{}
+		walker := New(func(info *WalkInfo) error {
+			called = append(called, info.Value.Interface())
+			return nil
+		}).WithIgnoreUnknownKinds(true)
+		state := newWalkerState(*walker)
+
+		val := struct{ A int }{A: 1}
+		info := state.newWalkerInfo(reflect.ValueOf(val), nil)
+		require.NoError(t, state.kindRoute(reflect.Kind(math.MaxUint), info))
+		require.Equal(t, []interface{}{val}, called)
+	})
 }
 
 //nolint:gocyclo
@@ -468,6 +617,54 @@ func TestWalker_WalkSlice(t *testing.T) {
 	}
 }
 
+func TestWalker_WalkAll(t *testing.T) {
+	type Shared struct {
+		Name string
+	}
+	type Owner struct {
+		ID     int
+		Shared *Shared
+	}
+	shared := &Shared{Name: "shared"}
+	a := Owner{ID: 1, Shared: shared}
+	b := Owner{ID: 2, Shared: shared}
+
+	t.Run("SharedVisitedOnce", func(t *testing.T) {
+		var names []string
+		var ids []int64
+		err := New(func(info *WalkInfo) error {
+			//nolint:exhaustive
+			switch info.Value.Kind() {
+			case reflect.String:
+				names = append(names, info.Value.String())
+			case reflect.Int:
+				ids = append(ids, info.Value.Int())
+			}
+			return nil
+		}).WalkAll(&a, nil, &b)
+		require.NoError(t, err)
+		require.Equal(t, []string{"shared"}, names)
+		require.Equal(t, []int64{1, 2}, ids)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			cnt++
+			return ErrStop
+		}).WalkAll(a, b)
+		require.NoError(t, err)
+		require.Equal(t, 1, cnt)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			return errTest
+		}).WalkAll(a, b)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
 func TestWalkString(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		val := ""
@@ -527,7 +724,7 @@ func TestWalkStruct(t *testing.T) {
 					if kind == reflect.String {
 						wasPrivate = true
 					}
-					if kind != reflect.Ptr {
+					if kind != reflect.Pointer {
 						require.NotZero(t, info.DirectPointer)
 					}
 					return nil
@@ -0,0 +1,111 @@
+package objwalker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_ParentChainLimit(t *testing.T) {
+	type L3 struct {
+		Val int
+	}
+	type L2 struct {
+		L3 L3
+	}
+	type L1 struct {
+		L2 L2
+	}
+
+	chainLen := func(info *WalkInfo) int {
+		res := 0
+		for parent := info.Parent; parent != nil; parent = parent.Parent {
+			res++
+		}
+		return res
+	}
+
+	chains := map[string]int{}
+	var stored []*WalkInfo
+	err := New(func(info *WalkInfo) error {
+		chains[info.TypeName()] = chainLen(info)
+		stored = append(stored, info)
+		return nil
+	}).WithParentChainLimit(2).Walk(L1{})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int{"L1": 0, "L2": 1, "L3": 2, "int": 3}, chains)
+
+	// after walk
+	require.Len(t, stored, 4)
+	require.Equal(t, 0, chainLen(stored[0]))
+	require.Equal(t, 1, chainLen(stored[1]))
+	require.Equal(t, 2, chainLen(stored[2]))
+	require.Equal(t, 3, chainLen(stored[3]))
+	require.Equal(t, RoleStructField, stored[1].Role())
+	require.Equal(t, ".L2.L3.Val", stored[3].Path())
+
+	t.Run("Siblings", func(t *testing.T) {
+		type Inner struct {
+			X int
+			Y int
+		}
+		type Middle struct {
+			Inner Inner
+		}
+		var stored []*WalkInfo
+		err := New(func(info *WalkInfo) error {
+			stored = append(stored, info)
+			return nil
+		}).WithParentChainLimit(2).Walk(struct{ Middle Middle }{})
+		require.NoError(t, err)
+
+		// chains and paths checked after walk, when all siblings are visited
+		chains := map[string]int{}
+		for _, info := range stored {
+			chains[info.Path()] = chainLen(info)
+		}
+		require.Equal(t, map[string]int{
+			"":                0,
+			".Middle":         1,
+			".Middle.Inner":   2,
+			".Middle.Inner.X": 3,
+			".Middle.Inner.Y": 3,
+		}, chains)
+		require.Same(t, stored[3].Parent, stored[4].Parent)
+	})
+
+	t.Run("SharedCopies", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+		}
+		var root *Node
+		for i := 0; i < 50; i++ {
+			root = &Node{Next: root}
+		}
+
+		const limit = 3
+		var stored []*WalkInfo
+		err := New(func(info *WalkInfo) error {
+			stored = append(stored, info)
+			return nil
+		}).WithParentChainLimit(limit).Walk(root)
+		require.NoError(t, err)
+
+		retained := map[*WalkInfo]bool{}
+		for depth, info := range stored {
+			l := chainLen(info)
+			if depth < limit {
+				require.Equal(t, depth, l)
+			} else {
+				require.GreaterOrEqual(t, l, limit)
+				require.Less(t, l, 2*limit)
+			}
+			for parent := info; parent != nil; parent = parent.Parent {
+				retained[parent] = true
+			}
+		}
+		// every value copied once at most
+		require.LessOrEqual(t, len(retained), 2*len(stored))
+	})
+}
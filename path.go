@@ -0,0 +1,53 @@
+package objwalker
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Path return position of value from root of walk, for example: .Field[2].Map[key]
+// Struct fields described as .Name, slice and array items as [index], map values as [key] and
+// map keys as {key}, methods as .Name(), text of keys is fmt.Sprint(key) or result of Walker.KeyFormatter.
+// Pointers and interfaces has no own path segments.
+// Path of root value is empty string.
+func (w *WalkInfo) Path() string {
+	var segments []string
+	for info := w; info != nil; info = info.Parent {
+		if segment := info.pathSegment(); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	var sb strings.Builder
+	for i := len(segments) - 1; i >= 0; i-- {
+		sb.WriteString(segments[i])
+	}
+	return sb.String()
+}
+
+func (w *WalkInfo) pathSegment() string {
+	switch {
+	case w.IsMethod:
+		return "." + w.Method.Name + "()"
+	case w.StructField != nil:
+		return "." + w.StructField.Name
+	case w.Index >= 0:
+		return "[" + strconv.Itoa(w.Index) + "]"
+	case w.isMapKey:
+		return "{" + formatMapKey(w.keyFormatter, w.mapKey) + "}"
+	case w.mapKey.IsValid():
+		return "[" + formatMapKey(w.keyFormatter, w.mapKey) + "]"
+	default:
+		return ""
+	}
+}
+
+// formatMapKey return text of map key for path
+func formatMapKey(formatter func(key reflect.Value) string, key reflect.Value) string {
+	if formatter != nil {
+		return formatter(key)
+	}
+	return fmt.Sprint(key)
+}
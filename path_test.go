@@ -0,0 +1,77 @@
+package objwalker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkInfo_Path(t *testing.T) {
+	type Item struct {
+		Name string
+	}
+	type S struct {
+		Items []Item
+		Map   map[string]int
+		Ptr   *Item
+	}
+	val := &S{
+		Items: []Item{{Name: "a"}, {Name: "b"}},
+		Map:   map[string]int{"key": 1},
+		Ptr:   &Item{Name: "c"},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		paths := map[interface{}]string{}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.String || info.Value.Kind() == reflect.Int {
+				paths[info.Value.Interface()] = info.Path()
+			}
+			if info.Parent == nil {
+				require.Empty(t, info.Path())
+			}
+			return nil
+		}).Walk(val))
+		require.Equal(t, map[interface{}]string{
+			"a":   ".Items[0].Name",
+			"b":   ".Items[1].Name",
+			"key": ".Map{key}",
+			1:     ".Map[key]",
+			"c":   ".Ptr.Name",
+		}, paths)
+	})
+
+	t.Run("TransparentPointers", func(t *testing.T) {
+		var paths []string
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Struct {
+				paths = append(paths, info.Path())
+			}
+			return nil
+		}).WithTransparentPointers(true).Walk(val))
+		require.Equal(t, []string{"", ".Items[0]", ".Items[1]", ".Ptr"}, paths)
+	})
+}
+
+func TestWalkInfo_PathKeyFormatter(t *testing.T) {
+	type Key struct {
+		X int
+	}
+	val := map[Key]int{{X: 1}: 10}
+
+	formatter := func(key reflect.Value) string {
+		return fmt.Sprintf("X=%d", key.Field(0).Int())
+	}
+	require.Equal(t, []string{"", "{X=1}", "{X=1}.X", "[X=1]"}, walkPaths(t, New(nil).WithKeyFormatter(formatter), val))
+	require.Equal(t, []string{"", "{{1}}", "{{1}}.X", "[{1}]"}, walkPaths(t, New(nil), val))
+
+	var walked []interface{}
+	err := New(func(info *WalkInfo) error {
+		walked = append(walked, info.Value.Interface())
+		return nil
+	}).WithKeyFormatter(formatter).WalkAt(val, "[X=1]")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{10}, walked)
+}
@@ -0,0 +1,142 @@
+package objwalker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_TransparentPointers(t *testing.T) {
+	type S struct {
+		X int
+		P *int
+	}
+	val := &S{X: 1}
+
+	var visited []reflect.Kind
+	var parents []*WalkInfo
+	require.NoError(t, New(func(info *WalkInfo) error {
+		visited = append(visited, info.Value.Kind())
+		parents = append(parents, info.Parent)
+		return nil
+	}).WithTransparentPointers(true).Walk(val))
+	require.Equal(t, []reflect.Kind{reflect.Struct, reflect.Int}, visited)
+	require.Nil(t, parents[0])
+	require.NotNil(t, parents[1])
+	require.Equal(t, reflect.Struct, parents[1].Value.Kind())
+
+	t.Run("LoopProtection", func(t *testing.T) {
+		type L struct {
+			P *L
+		}
+		l := &L{}
+		l.P = l
+
+		callTimes := 0
+		require.NoError(t, New(func(info *WalkInfo) error {
+			callTimes++
+			return nil
+		}).WithTransparentPointers(true).Walk(l))
+		require.Equal(t, 1, callTimes)
+	})
+
+	t.Run("SliceItems", func(t *testing.T) {
+		a, b := 1, 2
+		var siblings [][2]int
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				siblings = append(siblings, [2]int{info.SiblingIndex, info.SiblingCount})
+			}
+			return nil
+		}).WithTransparentPointers(true).Walk([]*int{&a, &b}))
+		require.Equal(t, [][2]int{{0, 2}, {1, 2}}, siblings)
+	})
+}
+
+func TestWalker_CollapsePointerChains(t *testing.T) {
+	walkDepths := func(val interface{}) []string {
+		var res []string
+		err := New(func(info *WalkInfo) error {
+			res = append(res, fmt.Sprintf("%v:%v:%v", info.Path(), info.Value.Kind(), info.PointerDepth))
+			return nil
+		}).WithCollapsePointerChains(true).Walk(val)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("Chain", func(t *testing.T) {
+		x := 1
+		px := &x
+		ppx := &px
+		require.Equal(t, []string{":int:3"}, walkDepths(&ppx))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var px *int
+		ppx := &px
+		require.Equal(t, []string{":ptr:2"}, walkDepths(&ppx))
+		require.Equal(t, []string{":ptr:0"}, walkDepths((*int)(nil)))
+	})
+
+	t.Run("StructFields", func(t *testing.T) {
+		type S struct {
+			Ptr   **int
+			Iface interface{}
+			Nil   *int
+		}
+		x, y := 2, 3
+		px := &x
+		require.Equal(t, []string{":struct:1", ".Ptr:int:2", ".Iface:int:2", ".Nil:ptr:0"}, walkDepths(&S{Ptr: &px, Iface: &y}))
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		var x interface{}
+		x = &x
+		require.Empty(t, walkDepths(&x))
+	})
+
+	t.Run("InterfaceHooks", func(t *testing.T) {
+		type S struct {
+			Iface interface{}
+		}
+		x := 1
+		val := &S{Iface: &x}
+
+		var registry TypeRegistry
+		registry.Register("int", reflect.TypeOf(0))
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithCollapsePointerChains(true).WithTypeRegistry(&registry).Walk(val)
+		require.ErrorIs(t, err, ErrUnregisteredType)
+
+		var resolved []string
+		paths := walkKindPaths(t, New(nil).WithCollapsePointerChains(true).
+			WithInterfaceResolver(func(info *WalkInfo) (reflect.Value, bool) {
+				resolved = append(resolved, fmt.Sprintf("%v:%v", info.Path(), info.PointerDepth))
+				return reflect.ValueOf("str"), true
+			}), val)
+		require.Equal(t, []string{":struct", ".Iface:string"}, paths)
+		require.Equal(t, []string{".Iface:0"}, resolved)
+
+		var types []reflect.Type
+		paths = walkKindPaths(t, New(nil).WithCollapsePointerChains(true).
+			WithInterfaceTypeCallback(func(info *WalkInfo, typ reflect.Type) error {
+				types = append(types, typ)
+				return ErrSkip
+			}), val)
+		require.Equal(t, []string{":struct"}, paths)
+		require.Equal(t, []reflect.Type{reflect.TypeOf(&x)}, types)
+	})
+
+	t.Run("TransparentPointers", func(t *testing.T) {
+		type S struct {
+			Nil   *int
+			Iface interface{}
+		}
+		paths := walkKindPaths(t, New(nil).WithCollapsePointerChains(true).WithTransparentPointers(true),
+			&S{Iface: (*int)(nil)})
+		require.Equal(t, []string{":struct"}, paths)
+	})
+}
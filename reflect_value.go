@@ -0,0 +1,30 @@
+package objwalker
+
+import (
+	"errors"
+	"reflect"
+)
+
+var reflectValueType = reflect.TypeOf(reflect.Value{})
+
+// wrappedReflectValue return value, wrapped by reflect.Value in info.
+// It can't read reflect.Value from unexported fields without DirectPointer.
+func wrappedReflectValue(info *WalkInfo) (reflect.Value, bool) {
+	if info.Value.CanInterface() {
+		return info.Value.Interface().(reflect.Value), true
+	}
+	if info.HasDirectPointer() {
+		return *(*reflect.Value)(info.DirectPointer), true
+	}
+	return reflect.Value{}, false
+}
+
+func (state *walkerState) walkReflectValue(info *WalkInfo, wrapped reflect.Value) error {
+	if err := state.call(info); err != nil {
+		if errors.Is(err, ErrSkip) {
+			return nil
+		}
+		return err
+	}
+	return state.walkValue(state.newWalkerInfo(wrapped, info))
+}
@@ -0,0 +1,44 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_FollowReflectValue(t *testing.T) {
+	type S struct {
+		Val     reflect.Value
+		private reflect.Value
+		Invalid reflect.Value
+	}
+	x := 5
+	val := &S{Val: reflect.ValueOf(1), private: reflect.ValueOf(&x), Invalid: reflect.Value{}}
+
+	t.Run("Enabled", func(t *testing.T) {
+		require.Equal(t, []string{
+			":ptr", ":struct",
+			".Val:struct", ".Val:int",
+			".private:struct", ".private:ptr", ".private:int",
+			".Invalid:struct",
+		}, walkKindPaths(t, New(nil).WithFollowReflectValue(true), val))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		kinds := walkKindPaths(t, New(nil), val)
+		require.NotContains(t, kinds, ".Val:int")
+	})
+
+	t.Run("Root", func(t *testing.T) {
+		var res []interface{}
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				res = append(res, info.Value.Interface())
+			}
+			return nil
+		}).WithFollowReflectValue(true).Walk(reflect.ValueOf(7))
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{7}, res)
+	})
+}
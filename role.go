@@ -0,0 +1,48 @@
+package objwalker
+
+// Role is position of value in parent value
+type Role int
+
+const (
+	// RoleRoot - value, passed to Walk
+	RoleRoot Role = iota
+
+	// RoleStructField - field of struct, WalkInfo.StructField describe it
+	RoleStructField
+
+	// RoleItem - item of slice or array, WalkInfo.Index is index of the item
+	RoleItem
+
+	// RoleMapKey - key of map entry
+	RoleMapKey
+
+	// RoleMapValue - value of map entry
+	RoleMapValue
+
+	// RoleElem - element of pointer or interface or child of Walkable value
+	RoleElem
+
+	// RoleMapEntry - entry of map, see Walker.MapEntryMode
+	RoleMapEntry
+)
+
+// Role return position of value in parent value.
+// Value pointed by transparent pointer (see Walker.TransparentPointers) has role of the pointer.
+func (w *WalkInfo) Role() Role {
+	switch {
+	case w.depth == 0:
+		return RoleRoot
+	case w.isMapKey:
+		return RoleMapKey
+	case w.isMapValue:
+		return RoleMapValue
+	case w.isMapEntry:
+		return RoleMapEntry
+	case w.StructField != nil:
+		return RoleStructField
+	case w.Index >= 0:
+		return RoleItem
+	default:
+		return RoleElem
+	}
+}
@@ -0,0 +1,88 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkInfo_Role(t *testing.T) {
+	type S struct {
+		Slice []int
+		Arr   [1]int
+		M     map[string]int
+		P     *int
+		I     interface{}
+	}
+
+	one := 1
+	val := S{Slice: []int{1}, Arr: [1]int{2}, M: map[string]int{"k": 3}, P: &one, I: "str"}
+
+	type record struct {
+		path string
+		kind reflect.Kind
+		role Role
+	}
+	var records []record
+	err := New(func(info *WalkInfo) error {
+		records = append(records, record{path: info.Path(), kind: info.Value.Kind(), role: info.Role()})
+		return nil
+	}).Walk(val)
+	require.NoError(t, err)
+
+	require.Equal(t, []record{
+		{path: "", kind: reflect.Struct, role: RoleRoot},
+		{path: ".Slice", kind: reflect.Slice, role: RoleStructField},
+		{path: ".Slice[0]", kind: reflect.Int, role: RoleItem},
+		{path: ".Arr", kind: reflect.Array, role: RoleStructField},
+		{path: ".Arr[0]", kind: reflect.Int, role: RoleItem},
+		{path: ".M", kind: reflect.Map, role: RoleStructField},
+		{path: ".M{k}", kind: reflect.String, role: RoleMapKey},
+		{path: ".M[k]", kind: reflect.Int, role: RoleMapValue},
+		{path: ".P", kind: reflect.Pointer, role: RoleStructField},
+		{path: ".P", kind: reflect.Int, role: RoleElem},
+		{path: ".I", kind: reflect.Interface, role: RoleStructField},
+		{path: ".I", kind: reflect.String, role: RoleElem},
+	}, records)
+}
+
+func TestWalkInfo_RoleTransparentPointers(t *testing.T) {
+	type T struct {
+		Val int
+	}
+	type K struct {
+		Name string
+	}
+
+	type record struct {
+		path string
+		kind reflect.Kind
+		role Role
+	}
+	var records []record
+	walkRecords := func(val interface{}) {
+		records = nil
+		err := New(func(info *WalkInfo) error {
+			records = append(records, record{path: info.Path(), kind: info.Value.Kind(), role: info.Role()})
+			return nil
+		}).WithTransparentPointers(true).Walk(val)
+		require.NoError(t, err)
+	}
+
+	walkRecords(map[string]*T{"k": {Val: 1}})
+	require.Equal(t, []record{
+		{path: "", kind: reflect.Map, role: RoleRoot},
+		{path: "{k}", kind: reflect.String, role: RoleMapKey},
+		{path: "[k]", kind: reflect.Struct, role: RoleMapValue},
+		{path: "[k].Val", kind: reflect.Int, role: RoleStructField},
+	}, records)
+
+	walkRecords(map[*K]int{{Name: "n"}: 2})
+	require.Equal(t, []record{
+		{path: "", kind: reflect.Map, role: RoleRoot},
+		{path: "{&{n}}", kind: reflect.Struct, role: RoleMapKey},
+		{path: "{&{n}}.Name", kind: reflect.String, role: RoleStructField},
+		{path: "[&{n}]", kind: reflect.Int, role: RoleMapValue},
+	}, records)
+}
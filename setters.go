@@ -0,0 +1,191 @@
+package objwalker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNotSettable mean value can't be changed: it isn't settable by reflection and has no DirectPointer
+	ErrNotSettable = errors.New("value is not settable")
+
+	// ErrNotAssignable mean new value type can't be assigned to walked value type
+	ErrNotAssignable = errors.New("value is not assignable")
+
+	// ErrKindMismatch mean typed setter called for value of other kind
+	ErrKindMismatch = errors.New("value kind mismatch")
+
+	// ErrNotMapEntry mean map entry method called for value, which isn't map key or map value
+	ErrNotMapEntry = errors.New("value is not map entry")
+
+	// ErrReadOnly mean setter called while walk with Walker.ReadOnly option
+	ErrReadOnly = errors.New("walk is read only")
+
+	// ErrMapKeyNotFound mean map entry can't be found by key of walked entry, for example for NaN keys
+	// or entry, deleted before
+	ErrMapKeyNotFound = errors.New("map key not found")
+)
+
+// TrySet set v to w.Value.
+// It use w.Value.Set if value settable and write through DirectPointer (for example for unexported fields) if not.
+// Return ErrNotSettable if no way to change value and ErrNotAssignable if v can't be assigned to the value type.
+func (w *WalkInfo) TrySet(v reflect.Value) error {
+	target, err := w.settableValue()
+	if err != nil {
+		return err
+	}
+	if !v.IsValid() {
+		return fmt.Errorf("can't assign invalid value to %v: %w", target.Type(), ErrNotAssignable)
+	}
+	if !v.Type().AssignableTo(target.Type()) {
+		return fmt.Errorf("can't assign %v to %v: %w", v.Type(), target.Type(), ErrNotAssignable)
+	}
+	target.Set(v)
+	return nil
+}
+
+// SetInt set int value of any int kind. It works for unexported fields through DirectPointer.
+func (w *WalkInfo) SetInt(v int64) error {
+	target, err := w.kindSettableValue(reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64)
+	if err != nil {
+		return err
+	}
+	if target.OverflowInt(v) {
+		return fmt.Errorf("value %v overflow %v: %w", v, target.Type(), ErrNotAssignable)
+	}
+	target.SetInt(v)
+	return nil
+}
+
+// SetString set string value. It works for unexported fields through DirectPointer.
+func (w *WalkInfo) SetString(v string) error {
+	target, err := w.kindSettableValue(reflect.String)
+	if err != nil {
+		return err
+	}
+	target.SetString(v)
+	return nil
+}
+
+// SetFloat set float value of any float kind. It works for unexported fields through DirectPointer.
+func (w *WalkInfo) SetFloat(v float64) error {
+	target, err := w.kindSettableValue(reflect.Float32, reflect.Float64)
+	if err != nil {
+		return err
+	}
+	if target.OverflowFloat(v) {
+		return fmt.Errorf("value %v overflow %v: %w", v, target.Type(), ErrNotAssignable)
+	}
+	target.SetFloat(v)
+	return nil
+}
+
+// SetBool set bool value. It works for unexported fields through DirectPointer.
+func (w *WalkInfo) SetBool(v bool) error {
+	target, err := w.kindSettableValue(reflect.Bool)
+	if err != nil {
+		return err
+	}
+	target.SetBool(v)
+	return nil
+}
+
+// SetZero set zero value of its type to w.Value. It works for unexported fields through DirectPointer.
+// Return ErrNotSettable if no way to change value, for example for map values.
+func (w *WalkInfo) SetZero() error {
+	target, err := w.settableValue()
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.Zero(target.Type()))
+	return nil
+}
+
+// OwningMap return map value, which contains the value, and true for map keys, map values and map entries.
+// It return false for other values.
+func (w *WalkInfo) OwningMap() (reflect.Value, bool) {
+	if !(w.isMapKey || w.isMapValue || w.isMapEntry) || w.Parent == nil || w.Parent.Value.Kind() != reflect.Map {
+		return reflect.Value{}, false
+	}
+	return w.Parent.Value, true
+}
+
+// DeleteFromMap delete entry of the value from OwningMap. Map can be changed during walk over it.
+// It works for maps in unexported fields through DirectPointer of the map.
+// Return ErrNotMapEntry for values, which aren't in map, ErrNotSettable if map can't be changed
+// and ErrMapKeyNotFound if map hasn't entry with the key (for example NaN key).
+func (w *WalkInfo) DeleteFromMap() error {
+	if w.readOnly {
+		return fmt.Errorf("can't delete map entry: %w", ErrReadOnly)
+	}
+	m, ok := w.OwningMap()
+	if !ok {
+		return fmt.Errorf("can't delete value of type %v from map: %w", w.Value.Type(), ErrNotMapEntry)
+	}
+	if !m.CanInterface() {
+		if !w.Parent.HasDirectPointer() {
+			return fmt.Errorf("can't delete entry from map %v: %w", m.Type(), ErrNotSettable)
+		}
+		m = reflect.NewAt(m.Type(), w.Parent.DirectPointer).Elem()
+	}
+	key := w.mapKey
+	if !key.CanInterface() {
+		// key of map from unexported field can't be used for change map, find same key in writable map
+		key = findMapKey(m, key)
+	} else if !m.MapIndex(key).IsValid() {
+		key = reflect.Value{}
+	}
+	if !key.IsValid() {
+		return fmt.Errorf("can't delete entry from map %v: %w", m.Type(), ErrMapKeyNotFound)
+	}
+	m.SetMapIndex(key, reflect.Value{})
+	return nil
+}
+
+// findMapKey return key of m, which equal to key or invalid value if m hasn't the key
+func findMapKey(m reflect.Value, key reflect.Value) reflect.Value {
+	iterator := m.MapRange()
+	for iterator.Next() {
+		if iterator.Key().Equal(key) {
+			return iterator.Key()
+		}
+	}
+	return reflect.Value{}
+}
+
+// CanModify report about value can be changed by TrySet and other setters of WalkInfo:
+// it is settable by reflection or has DirectPointer.
+// It always false for map keys and map values, because them are copies of map content,
+// and for values, walked with Walker.ReadOnly option.
+func (w *WalkInfo) CanModify() bool {
+	if w.readOnly || w.isMapKey || w.isMapValue {
+		return false
+	}
+	return w.Value.CanSet() || w.HasDirectPointer()
+}
+
+// settableValue return settable value, point to same data as w.Value
+func (w *WalkInfo) settableValue() (reflect.Value, error) {
+	if w.readOnly {
+		return reflect.Value{}, fmt.Errorf("can't set value of type %v: %w", w.Value.Type(), ErrReadOnly)
+	}
+	if !w.CanModify() {
+		return reflect.Value{}, fmt.Errorf("can't set value of type %v: %w", w.Value.Type(), ErrNotSettable)
+	}
+	if w.Value.CanSet() {
+		return w.Value, nil
+	}
+	return reflect.NewAt(w.Value.Type(), w.DirectPointer).Elem(), nil
+}
+
+// kindSettableValue check value kind and return settable value
+func (w *WalkInfo) kindSettableValue(kinds ...reflect.Kind) (reflect.Value, error) {
+	kind := w.Value.Kind()
+	for _, k := range kinds {
+		if k == kind {
+			return w.settableValue()
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("can't set %v to value of kind %v: %w", kinds, kind, ErrKindMismatch)
+}
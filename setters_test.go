@@ -0,0 +1,392 @@
+package objwalker
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkInfo_TrySet(t *testing.T) {
+	type S struct {
+		Pub  int
+		priv string
+	}
+
+	t.Run("Settable", func(t *testing.T) {
+		var v S
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				require.True(t, info.Value.CanSet())
+				require.NoError(t, info.TrySet(reflect.ValueOf(1)))
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, 1, v.Pub)
+	})
+
+	t.Run("DirectPointer", func(t *testing.T) {
+		var v S
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.String {
+				require.False(t, info.Value.CanSet())
+				require.NoError(t, info.TrySet(reflect.ValueOf("test")))
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, "test", v.priv)
+	})
+
+	t.Run("NotAssignable", func(t *testing.T) {
+		var v S
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				require.ErrorIs(t, info.TrySet(reflect.ValueOf("str")), ErrNotAssignable)
+				require.ErrorIs(t, info.TrySet(reflect.Value{}), ErrNotAssignable)
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, 0, v.Pub)
+	})
+
+	t.Run("NotSettable", func(t *testing.T) {
+		v := map[int]int{1: 2}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapValue() {
+				require.ErrorIs(t, info.TrySet(reflect.ValueOf(3)), ErrNotSettable)
+			}
+			return nil
+		}).Walk(v))
+		require.Equal(t, map[int]int{1: 2}, v)
+	})
+}
+
+func TestWalkInfo_SetZero(t *testing.T) {
+	type S struct {
+		Name     string
+		password string
+		Tags     []string
+	}
+
+	t.Run("Unexported", func(t *testing.T) {
+		v := S{Name: "name", password: "secret", Tags: []string{"a"}}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.StructField != nil && info.StructField.Name == "password" {
+				require.False(t, info.Value.CanSet())
+				require.NoError(t, info.SetZero())
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, "", v.password)
+		require.Equal(t, "name", v.Name)
+	})
+
+	t.Run("Settable", func(t *testing.T) {
+		v := S{Name: "name", Tags: []string{"a"}}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.StructField != nil && info.StructField.Name == "Tags" {
+				require.NoError(t, info.SetZero())
+				return ErrSkip
+			}
+			return nil
+		}).Walk(&v))
+		require.Nil(t, v.Tags)
+	})
+
+	t.Run("NotSettable", func(t *testing.T) {
+		v := map[string]string{"k": "v"}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapValue() {
+				require.ErrorIs(t, info.SetZero(), ErrNotSettable)
+			}
+			return nil
+		}).Walk(v))
+		require.Equal(t, map[string]string{"k": "v"}, v)
+	})
+}
+
+func TestWalker_FieldRewriter(t *testing.T) {
+	type Inner struct {
+		Secret string
+		Count  int
+	}
+	type S struct {
+		Name  string
+		token string
+		Inner *Inner
+		Tags  []string
+	}
+	redact := func(info *WalkInfo) (reflect.Value, bool) {
+		if info.Value.Kind() == reflect.String {
+			return reflect.ValueOf("REDACTED"), true
+		}
+		return reflect.Value{}, false
+	}
+
+	t.Run("Redact", func(t *testing.T) {
+		v := S{Name: "name", token: "token", Inner: &Inner{Secret: "secret", Count: 1}, Tags: []string{"tag"}}
+		var walkedStrings []string
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.String {
+				walkedStrings = append(walkedStrings, info.Value.String())
+			}
+			return nil
+		}).WithFieldRewriter(redact).Walk(&v))
+		require.Equal(t, S{
+			Name:  "REDACTED",
+			token: "REDACTED",
+			Inner: &Inner{Secret: "REDACTED", Count: 1},
+			Tags:  []string{"tag"},
+		}, v)
+		require.Equal(t, []string{"REDACTED", "REDACTED", "REDACTED", "tag"}, walkedStrings)
+	})
+
+	t.Run("NotSettable", func(t *testing.T) {
+		v := S{Name: "name"}
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithFieldRewriter(redact).Walk(v)
+		require.ErrorIs(t, err, ErrNotSettable)
+		require.Equal(t, "name", v.Name)
+	})
+}
+
+func TestWalker_ReadOnly(t *testing.T) {
+	type S struct {
+		Pub  int
+		priv int
+	}
+
+	setAll := func(w *Walker, v *S) []error {
+		var errs []error
+		w.callback = func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				errs = append(errs, info.SetInt(5), info.SetZero(), info.TrySet(reflect.ValueOf(6)))
+			}
+			return nil
+		}
+		require.NoError(t, w.Walk(v))
+		return errs
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		v := S{Pub: 1, priv: 2}
+		errs := setAll(New(nil).WithReadOnly(true), &v)
+		require.Len(t, errs, 6)
+		for _, err := range errs {
+			require.ErrorIs(t, err, ErrReadOnly)
+		}
+		require.Equal(t, S{Pub: 1, priv: 2}, v)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		v := S{Pub: 1, priv: 2}
+		errs := setAll(New(nil), &v)
+		require.Len(t, errs, 6)
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		require.Equal(t, S{Pub: 6, priv: 6}, v)
+	})
+
+	t.Run("CanModify", func(t *testing.T) {
+		v := S{}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			require.False(t, info.CanModify())
+			return nil
+		}).WithReadOnly(true).Walk(&v))
+	})
+}
+
+func TestWalkInfo_DeleteFromMap(t *testing.T) {
+	type S struct {
+		Pub  map[string]int
+		priv map[string]int
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		v := S{Pub: map[string]int{"a": 1, "b": 2, "c": 3}, priv: map[string]int{"a": 1, "b": 2}}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapKey() && info.Value.String() == "b" {
+				m, ok := info.OwningMap()
+				require.True(t, ok)
+				require.Equal(t, reflect.Map, m.Kind())
+				require.NoError(t, info.DeleteFromMap())
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, map[string]int{"a": 1, "c": 3}, v.Pub)
+		require.Equal(t, map[string]int{"a": 1}, v.priv)
+	})
+
+	t.Run("MapValue", func(t *testing.T) {
+		v := map[string]int{"a": 1, "b": 2}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapValue() && info.Value.Int() == 1 {
+				require.NoError(t, info.DeleteFromMap())
+			}
+			return nil
+		}).Walk(v))
+		require.Equal(t, map[string]int{"b": 2}, v)
+	})
+
+	t.Run("NotMapEntry", func(t *testing.T) {
+		v := S{}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			_, ok := info.OwningMap()
+			require.False(t, ok)
+			require.ErrorIs(t, info.DeleteFromMap(), ErrNotMapEntry)
+			return nil
+		}).Walk(&v))
+	})
+
+	t.Run("NotSettable", func(t *testing.T) {
+		v := S{priv: map[string]int{"a": 1}}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapKey() {
+				require.ErrorIs(t, info.DeleteFromMap(), ErrNotSettable)
+			}
+			return nil
+		}).Walk(v))
+		require.Len(t, v.priv, 1)
+	})
+
+	t.Run("KeyNotFound", func(t *testing.T) {
+		v := S{Pub: map[string]int{"a": 1}, priv: map[string]int{"a": 1}}
+		var errs []error
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapValue() {
+				require.NoError(t, info.DeleteFromMap())
+				errs = append(errs, info.DeleteFromMap())
+			}
+			return nil
+		}).Walk(&v))
+		require.Len(t, errs, 2)
+		for _, err := range errs {
+			require.ErrorIs(t, err, ErrMapKeyNotFound)
+		}
+
+		type N struct {
+			Pub  map[float64]int
+			priv map[float64]int
+		}
+		n := N{Pub: map[float64]int{math.NaN(): 1}, priv: map[float64]int{math.NaN(): 1}}
+		errs = nil
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapKey() {
+				errs = append(errs, info.DeleteFromMap())
+			}
+			return nil
+		}).Walk(&n))
+		require.Len(t, errs, 2)
+		for _, err := range errs {
+			require.ErrorIs(t, err, ErrMapKeyNotFound)
+		}
+		require.Len(t, n.Pub, 1)
+		require.Len(t, n.priv, 1)
+	})
+}
+
+func TestWalkInfo_TypedSetters(t *testing.T) {
+	type S struct {
+		i int8
+		s string
+		f float32
+		b bool
+	}
+
+	t.Run("Ok", func(t *testing.T) {
+		var v S
+		require.NoError(t, New(func(info *WalkInfo) error {
+			switch info.Value.Kind() {
+			case reflect.Int8:
+				require.NoError(t, info.SetInt(1))
+			case reflect.String:
+				require.NoError(t, info.SetString("str"))
+			case reflect.Float32:
+				require.NoError(t, info.SetFloat(1.5))
+			case reflect.Bool:
+				require.NoError(t, info.SetBool(true))
+			default:
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, S{i: 1, s: "str", f: 1.5, b: true}, v)
+	})
+
+	t.Run("KindMismatch", func(t *testing.T) {
+		var v S
+		require.NoError(t, New(func(info *WalkInfo) error {
+			switch info.Value.Kind() {
+			case reflect.Int8:
+				require.ErrorIs(t, info.SetString("str"), ErrKindMismatch)
+				require.ErrorIs(t, info.SetFloat(1), ErrKindMismatch)
+			case reflect.String:
+				require.ErrorIs(t, info.SetInt(1), ErrKindMismatch)
+				require.ErrorIs(t, info.SetBool(true), ErrKindMismatch)
+			default:
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, S{}, v)
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		var v S
+		require.NoError(t, New(func(info *WalkInfo) error {
+			switch info.Value.Kind() {
+			case reflect.Int8:
+				require.ErrorIs(t, info.SetInt(1000), ErrNotAssignable)
+			case reflect.Float32:
+				require.ErrorIs(t, info.SetFloat(1e300), ErrNotAssignable)
+			default:
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, S{}, v)
+	})
+
+	t.Run("NotSettable", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			require.ErrorIs(t, info.SetInt(1), ErrNotSettable)
+			return nil
+		}).Walk(1))
+	})
+}
+
+func TestWalkInfo_CanModify(t *testing.T) {
+	t.Run("Map", func(t *testing.T) {
+		v := map[string]int{"1": 2}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.IsMapKey() || info.IsMapValue() {
+				require.False(t, info.CanModify())
+			}
+			return nil
+		}).Walk(&v))
+	})
+
+	t.Run("Struct", func(t *testing.T) {
+		type S struct {
+			Pub  int
+			priv int
+		}
+		var v S
+		fields := 0
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				fields++
+				require.True(t, info.CanModify())
+			}
+			return nil
+		}).Walk(&v))
+		require.Equal(t, 2, fields)
+	})
+
+	t.Run("Unaddressable", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			require.False(t, info.CanModify())
+			return nil
+		}).Walk(1))
+	})
+}
@@ -0,0 +1,195 @@
+package objwalker
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_SkipFunc(t *testing.T) {
+	type Secret struct {
+		Password string
+	}
+	type S struct {
+		Name   string
+		Secret Secret
+		Age    int
+	}
+	val := S{Name: "name", Secret: Secret{Password: "pass"}, Age: 3}
+
+	var visited []interface{}
+	require.NoError(t, New(func(info *WalkInfo) error {
+		visited = append(visited, info.Value.Interface())
+		return nil
+	}).WithSkipFunc(func(info *WalkInfo) bool {
+		return info.StructField != nil && info.StructField.Name == "Secret"
+	}).Walk(val))
+	require.Equal(t, []interface{}{val, "name", 3}, visited)
+}
+
+func TestWalker_StopOnType(t *testing.T) {
+	type Inner struct {
+		Before int
+		Buf    *bytes.Buffer
+		After  string
+	}
+	type Outer struct {
+		Name  string
+		Inner Inner
+		Other *bytes.Buffer
+		Tail  int
+	}
+
+	val := Outer{Inner: Inner{Buf: &bytes.Buffer{}}, Other: &bytes.Buffer{}}
+
+	var names []string
+	err := New(func(info *WalkInfo) error {
+		if info.StructField != nil {
+			names = append(names, info.StructField.Name)
+		}
+		return nil
+	}).WithStopOnType(reflect.TypeOf((*bytes.Buffer)(nil))).Walk(val)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Name", "Inner", "Before", "Buf"}, names)
+}
+
+func TestWalker_OncePerType(t *testing.T) {
+	type Item struct {
+		Name  string
+		Value int
+		Tags  []string
+	}
+	val := []Item{
+		{Name: "a", Value: 1},
+		{Name: "b", Value: 2, Tags: []string{"x", "y"}},
+	}
+
+	calls := map[reflect.Type]int{}
+	var strValues []string
+	stats, err := New(func(info *WalkInfo) error {
+		calls[info.Value.Type()]++
+		if info.Value.Kind() == reflect.String {
+			strValues = append(strValues, info.Value.String())
+		}
+		return nil
+	}).WithOncePerType(true).WithStringInterning(true).WalkWithStats(val)
+	require.NoError(t, err)
+
+	require.Equal(t, map[reflect.Type]int{
+		reflect.TypeOf(val):        1,
+		reflect.TypeOf(Item{}):     1,
+		reflect.TypeOf(""):         1,
+		reflect.TypeOf(0):          1,
+		reflect.TypeOf([]string{}): 1,
+	}, calls)
+	require.Equal(t, []string{"a"}, strValues)
+
+	// walker walk into values without callback
+	require.Equal(t, map[string]int{"a": 1, "b": 1, "x": 1, "y": 1}, stats.StringCounts)
+}
+
+func TestWalker_AllowedPackages(t *testing.T) {
+	type Local struct {
+		Name string
+	}
+	type S struct {
+		Local Local
+		Time  time.Time
+		Ptr   *time.Location
+	}
+	val := S{Time: time.Unix(0, 0).UTC(), Ptr: time.UTC}
+
+	require.Equal(t, []string{
+		":struct", ".Local:struct", ".Local.Name:string", ".Time:struct", ".Ptr:ptr", ".Ptr:struct",
+	}, walkKindPaths(t, New(nil).WithAllowedPackages("github.com/rekby/"), val))
+
+	require.Contains(t, walkKindPaths(t, New(nil), val), ".Time.wall:uint64")
+
+	t.Run("PackageBoundary", func(t *testing.T) {
+		for _, allowed := range []string{"example.com/foo", "example.com/foo/"} {
+			state := newWalkerState(*New(nil).WithAllowedPackages(allowed))
+			require.True(t, state.isAllowedPackage("example.com/foo"), allowed)
+			require.True(t, state.isAllowedPackage("example.com/foo/bar"), allowed)
+			require.False(t, state.isAllowedPackage("example.com/foobar"), allowed)
+			require.False(t, state.isAllowedPackage("example.com"), allowed)
+		}
+	})
+}
+
+func TestWalker_ChanDir(t *testing.T) {
+	type S struct {
+		Both chan int
+		Send chan<- int
+		Recv <-chan int
+		Num  int
+	}
+	ch := make(chan int)
+	val := S{Both: ch, Send: ch, Recv: ch, Num: 1}
+
+	walkDirs := func(w *Walker) []string {
+		return walkCollect(t, w, val, func(info *WalkInfo) (string, bool) {
+			return info.Path() + ":" + info.ChanDir.String(), true
+		})
+	}
+
+	t.Run("Direction", func(t *testing.T) {
+		require.Equal(t, []string{":ChanDir0", ".Both:chan", ".Send:chan<-", ".Recv:<-chan", ".Num:ChanDir0"},
+			walkDirs(New(nil)))
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		require.Equal(t, []string{":ChanDir0", ".Both:chan", ".Recv:<-chan", ".Num:ChanDir0"},
+			walkDirs(New(nil).WithSkipChanDir(reflect.SendDir)))
+	})
+}
+
+func TestWalker_SkipValue(t *testing.T) {
+	type S struct {
+		A     string
+		B     string
+		List  []string
+		M     map[string]string
+		hide  string
+		Other int
+	}
+	val := S{
+		A:    "ignore",
+		B:    "keep",
+		List: []string{"ignore", "x"},
+		M:    map[string]string{"k": "ignore"},
+		hide: "ignore",
+	}
+
+	t.Run("Addressable", func(t *testing.T) {
+		require.Equal(t, []string{"", "", ".B", ".List", ".List[1]", ".M", ".M{k}", ".Other"},
+			walkPaths(t, New(nil).WithSkipValue("ignore"), &val))
+	})
+
+	t.Run("NotAddressable", func(t *testing.T) {
+		require.Equal(t, []string{"", ".B", ".List", ".List[1]", ".M", ".M{k}", ".hide", ".Other"},
+			walkPaths(t, New(nil).WithSkipValue("ignore"), val))
+	})
+
+	t.Run("OtherType", func(t *testing.T) {
+		require.Equal(t, []string{"", "", ".A", ".B", ".List", ".List[0]", ".List[1]", ".M", ".M{k}", ".M[k]", ".hide"},
+			walkPaths(t, New(nil).WithSkipValue(0), &val))
+	})
+}
+
+func TestWalker_SkipRoot(t *testing.T) {
+	type S struct {
+		A int
+		P *int
+	}
+	vInt := 2
+
+	var visited []string
+	require.NoError(t, New(func(info *WalkInfo) error {
+		visited = append(visited, info.Path()+":"+info.Value.Kind().String())
+		return nil
+	}).WithSkipRoot(true).Walk(&S{A: 1, P: &vInt}))
+	require.Equal(t, []string{".A:int", ".P:ptr", ".P:int"}, visited)
+}
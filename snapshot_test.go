@@ -0,0 +1,44 @@
+package objwalker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_Snapshot(t *testing.T) {
+	type S struct {
+		Val   int
+		Slice []string
+	}
+	val := &S{Val: 1, Slice: []string{"a"}}
+
+	var visited []interface{}
+	require.NoError(t, New(func(info *WalkInfo) error {
+		if info.Parent == nil {
+			require.NotSame(t, val, info.Value.Interface())
+			val.Val = 2
+			val.Slice[0] = "b"
+		}
+		visited = append(visited, info.Value.Interface())
+		return nil
+	}).WithSnapshot(true).Walk(val))
+	require.Equal(t, []interface{}{
+		&S{Val: 1, Slice: []string{"a"}},
+		S{Val: 1, Slice: []string{"a"}},
+		1,
+		[]string{"a"},
+		"a",
+	}, visited)
+
+	t.Run("SelfContainingSlice", func(t *testing.T) {
+		s := []interface{}{nil, 1}
+		s[0] = s
+
+		walked := walkKindPaths(t, New(nil), s)
+		snapshotWalked := walkKindPaths(t, New(nil).WithSnapshot(true), s)
+		// root of snapshot doesn't share backing array with nested slice, so snapshot walk visit more values
+		require.Equal(t, walked[:3], snapshotWalked[:3])
+		require.Contains(t, snapshotWalked, "[1]:int")
+	})
+}
@@ -0,0 +1,58 @@
+package objwalker
+
+import (
+	"reflect"
+	"time"
+)
+
+// WalkStats contains statistic of walk, returned by Walker.WalkWithStats
+type WalkStats struct {
+	// LoopProtectionDisabled true if loop protection was disabled during walk
+	// because count of visited values exceeded Walker.VisitedMaxEntries
+	LoopProtectionDisabled bool
+
+	// CallbackDurationByKind is summary time of callback calls for every kind of values,
+	// it filled if Walker.Profiling enabled only
+	CallbackDurationByKind map[reflect.Kind]time.Duration
+
+	// StringCounts is count of occurrences of every distinct walked string,
+	// it filled if Walker.StringInterning enabled only
+	StringCounts map[string]int
+
+	// MaxSliceLen, MaxMapLen and MaxArrayLen are max length of walked slices, maps and arrays
+	MaxSliceLen int
+	MaxMapLen   int
+	MaxArrayLen int
+}
+
+func newWalkStats(opts Walker) WalkStats {
+	res := WalkStats{
+		LoopProtectionDisabled: false,
+		CallbackDurationByKind: nil,
+		StringCounts:           nil,
+		MaxSliceLen:            0,
+		MaxMapLen:              0,
+		MaxArrayLen:            0,
+	}
+	if opts.Profiling {
+		res.CallbackDurationByKind = make(map[reflect.Kind]time.Duration)
+	}
+	if opts.StringInterning {
+		res.StringCounts = make(map[string]int)
+	}
+	return res
+}
+
+// updateMax set max to val if val greater
+func updateMax(max *int, val int) {
+	if val > *max {
+		*max = val
+	}
+}
+
+// WalkWithStats is same as Walk, but return statistic of walk
+func (w Walker) WalkWithStats(v interface{}) (WalkStats, error) {
+	walker := newWalkerState(w)
+	err := walker.walk(v, checkValue())
+	return walker.stats, err
+}
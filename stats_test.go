@@ -0,0 +1,128 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_VisitedMaxEntries(t *testing.T) {
+	x := 1
+	val := []*int{&x, &x, &x, &x}
+
+	walk := func(maxEntries int) (int, WalkStats) {
+		intCalls := 0
+		stats, err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Int {
+				intCalls++
+			}
+			return nil
+		}).WithVisitedMaxEntries(maxEntries).WalkWithStats(val)
+		require.NoError(t, err)
+		return intCalls, stats
+	}
+
+	t.Run("Unlimited", func(t *testing.T) {
+		intCalls, stats := walk(0)
+		require.Equal(t, 1, intCalls)
+		require.False(t, stats.LoopProtectionDisabled)
+	})
+
+	t.Run("Enough", func(t *testing.T) {
+		intCalls, stats := walk(100)
+		require.Equal(t, 1, intCalls)
+		require.False(t, stats.LoopProtectionDisabled)
+	})
+
+	t.Run("Exceeded", func(t *testing.T) {
+		// remembered: val[0], x, val[1], then protection disabled on val[2]
+		intCalls, stats := walk(3)
+		require.Equal(t, 3, intCalls)
+		require.True(t, stats.LoopProtectionDisabled)
+	})
+}
+
+func TestWalker_Profiling(t *testing.T) {
+	val := struct {
+		A int
+		B string
+		C int
+	}{}
+
+	t.Run("Enabled", func(t *testing.T) {
+		stats, err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.String {
+				time.Sleep(10 * time.Millisecond)
+			}
+			return nil
+		}).WithProfiling(true).WalkWithStats(val)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, stats.CallbackDurationByKind[reflect.String], 10*time.Millisecond)
+		require.Greater(t, stats.CallbackDurationByKind[reflect.String], stats.CallbackDurationByKind[reflect.Int])
+		require.Greater(t, stats.CallbackDurationByKind[reflect.String], stats.CallbackDurationByKind[reflect.Struct])
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		stats, err := New(func(info *WalkInfo) error {
+			return nil
+		}).WalkWithStats(val)
+		require.NoError(t, err)
+		require.Nil(t, stats.CallbackDurationByKind)
+	})
+}
+
+func TestWalker_StringInterning(t *testing.T) {
+	type S struct {
+		A     string
+		B     string
+		C     []string
+		M     map[string]string
+		Other int
+	}
+	val := S{A: "x", B: "y", C: []string{"x", "z", "x"}, M: map[string]string{"y": "x"}}
+
+	t.Run("Enabled", func(t *testing.T) {
+		stats, err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithStringInterning(true).WalkWithStats(val)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"x": 4, "y": 2, "z": 1}, stats.StringCounts)
+		require.Equal(t, "x", val.A)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		stats, err := New(func(info *WalkInfo) error {
+			return nil
+		}).WalkWithStats(val)
+		require.NoError(t, err)
+		require.Nil(t, stats.StringCounts)
+	})
+}
+
+func TestWalker_MaxCollectionLen(t *testing.T) {
+	type S struct {
+		Small  []int
+		Big    []int
+		Nested [][]int
+		Arr    [3]int
+		BigArr [7]byte
+		M      map[string][]int
+		Empty  map[int]int
+	}
+	val := S{
+		Small:  []int{1},
+		Big:    make([]int, 10),
+		Nested: [][]int{make([]int, 12)},
+		M:      map[string][]int{"a": nil, "b": nil, "c": nil, "d": nil},
+	}
+
+	stats, err := New(func(info *WalkInfo) error {
+		return nil
+	}).WalkWithStats(val)
+	require.NoError(t, err)
+	require.Equal(t, 12, stats.MaxSliceLen)
+	require.Equal(t, 4, stats.MaxMapLen)
+	require.Equal(t, 7, stats.MaxArrayLen)
+}
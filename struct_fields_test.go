@@ -0,0 +1,243 @@
+package objwalker
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_FieldTagFilter(t *testing.T) {
+	type Inner struct {
+		Tagged   int `walk:"yes"`
+		Untagged int
+	}
+	type S struct {
+		A     int   `walk:"yes"`
+		B     int   `walk:"no"`
+		C     int   `json:"c"`
+		Inner Inner `walk:"yes"`
+		Skip  Inner
+	}
+
+	fieldNames := func(info *WalkInfo) (string, bool) {
+		if info.StructField == nil {
+			return "", false
+		}
+		return info.StructField.Name, true
+	}
+
+	require.Equal(t, []string{"A", "Inner", "Tagged"}, walkCollect(t, New(nil).WithFieldTagFilter("walk", "yes"), S{}, fieldNames))
+	require.Equal(t, []string{"A", "B", "Inner", "Tagged"}, walkCollect(t, New(nil).WithFieldTagFilter("walk", ""), S{}, fieldNames))
+	require.Equal(t, []string{"C"}, walkCollect(t, New(nil).WithFieldTagFilter("json", ""), S{}, fieldNames))
+
+	siblings := func(info *WalkInfo) (string, bool) {
+		if info.StructField == nil {
+			return "", false
+		}
+		return fmt.Sprintf("%v:%v/%v", info.StructField.Name, info.SiblingIndex, info.SiblingCount), true
+	}
+	require.Equal(t, []string{"A:0/2", "Inner:3/2", "Tagged:0/1"}, walkCollect(t, New(nil).WithFieldTagFilter("walk", "yes"), S{}, siblings))
+	require.Equal(t, []string{"A:0/5", "B:1/5", "C:2/5", "Inner:3/5", "Tagged:0/2"}, walkCollect(t, New(nil), S{}, siblings)[:5])
+}
+
+func TestWalker_StructFieldOrder(t *testing.T) {
+	type S struct {
+		B int
+		C int
+		A int
+	}
+
+	for _, test := range []struct {
+		name  string
+		order FieldOrder
+		names []string
+	}{
+		{"Declaration", FieldOrderDeclaration, []string{"B", "C", "A"}},
+		{"Alphabetical", FieldOrderAlphabetical, []string{"A", "B", "C"}},
+		{"Reverse", FieldOrderReverse, []string{"A", "C", "B"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var names []string
+			err := New(func(info *WalkInfo) error {
+				if info.StructField != nil {
+					names = append(names, info.StructField.Name)
+					require.Equal(t, info.StructField.Index, []int{info.SiblingIndex})
+				}
+				return nil
+			}).WithStructFieldOrder(test.order).Walk(S{})
+			require.NoError(t, err)
+			require.Equal(t, test.names, names)
+		})
+	}
+
+	t.Run("Cache", func(t *testing.T) {
+		typ := reflect.TypeOf(S{})
+		require.Nil(t, newWalkerState(*New(nil)).fieldsOrder(typ))
+
+		state := newWalkerState(*New(nil).WithStructFieldOrder(FieldOrderAlphabetical))
+		order := state.fieldsOrder(typ)
+		require.Equal(t, []int{2, 0, 1}, order)
+		require.Same(t, &order[0], &state.fieldsOrder(typ)[0])
+	})
+}
+
+type testEmbeddedReader struct {
+	Data string
+	pos  int
+}
+
+func (r *testEmbeddedReader) Read(p []byte) (int, error) {
+	n := copy(p, r.Data[r.pos:])
+	r.pos += n
+	return n, nil
+}
+
+type testReaderFunc func(p []byte) (int, error)
+
+func (f testReaderFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestWalker_PromoteEmbeddedInterfaces(t *testing.T) {
+	type S struct {
+		io.Reader
+		Name string
+	}
+
+	walkNames := func(w *Walker, val interface{}) []string {
+		return walkCollect(t, w, val, func(info *WalkInfo) (string, bool) {
+			return info.Path(), info.StructField != nil
+		})
+	}
+
+	val := S{Reader: &testEmbeddedReader{Data: "data", pos: 1}, Name: "name"}
+
+	t.Run("Enabled", func(t *testing.T) {
+		require.Equal(t, []string{".Data", ".pos", ".Name"}, walkNames(New(nil).WithPromoteEmbeddedInterfaces(true), val))
+	})
+
+	t.Run("Positions", func(t *testing.T) {
+		positions := walkCollect(t, New(nil).WithPromoteEmbeddedInterfaces(true), val, func(info *WalkInfo) (string, bool) {
+			return fmt.Sprintf("%v:%v/%v:%v", info.Path(), info.SiblingIndex, info.SiblingCount, info.FieldIndexPath()), info.StructField != nil
+		})
+		require.Equal(t, []string{".Data:-1/-1:[]", ".pos:-1/-1:[]", ".Name:1/2:[1]"}, positions)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		require.Equal(t, []string{".Reader", ".Reader.Data", ".Reader.pos", ".Name"}, walkNames(New(nil), val))
+	})
+
+	t.Run("NilInterface", func(t *testing.T) {
+		require.Equal(t, []string{".Reader", ".Name"}, walkNames(New(nil).WithPromoteEmbeddedInterfaces(true), S{}))
+	})
+
+	t.Run("SelfReference", func(t *testing.T) {
+		val := &S{Name: "name"}
+		val.Reader = val
+		require.Equal(t, []string{".Name"}, walkNames(New(nil).WithPromoteEmbeddedInterfaces(true), val))
+		// copy of struct point to original struct, fields of the original are promoted once
+		require.Equal(t, []string{".Name", ".Name"}, walkNames(New(nil).WithPromoteEmbeddedInterfaces(true), *val))
+	})
+
+	t.Run("NotStruct", func(t *testing.T) {
+		val := S{Reader: testReaderFunc(func(p []byte) (int, error) {
+			return 0, io.EOF
+		})}
+		require.Equal(t, []string{".Reader", ".Name"}, walkNames(New(nil).WithPromoteEmbeddedInterfaces(true), val))
+	})
+}
+
+type testMethods struct {
+	Name string
+}
+
+func (m testMethods) Greet(prefix string) string {
+	return prefix + m.Name
+}
+
+func (m testMethods) Len() int {
+	return len(m.Name)
+}
+
+func (m *testMethods) SetName(name string) {
+	m.Name = name
+}
+
+func TestWalker_WalkMethods(t *testing.T) {
+	val := &testMethods{Name: "name"}
+
+	var methods []string
+	err := New(func(info *WalkInfo) error {
+		if info.IsMethod {
+			require.Equal(t, reflect.Func, info.Value.Kind())
+			require.Equal(t, -1, info.SiblingIndex)
+			require.Equal(t, -1, info.SiblingCount)
+			methods = append(methods, fmt.Sprintf("%v %v.%v %v", info.Path(), info.Parent.Value.Type(), info.Method.Name, info.Value.Type()))
+		}
+		return nil
+	}).WithWalkMethods(true).Walk(val)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		".Greet() *objwalker.testMethods.Greet func(string) string",
+		".Len() *objwalker.testMethods.Len func() int",
+		".SetName() *objwalker.testMethods.SetName func(string)",
+	}, methods)
+	require.Equal(t, "name", val.Name)
+
+	require.Equal(t, []string{"", ".Greet()", ".Len()", ".Name"}, walkPaths(t, New(nil).WithWalkMethods(true), *val))
+
+	require.Equal(t, []string{
+		":struct", ".M:ptr", ".M.Greet():func", ".M.Len():func", ".M.SetName():func", ".M:struct", ".M.Name:string",
+	}, walkKindPaths(t, New(nil).WithWalkMethods(true), struct{ M *testMethods }{M: val}))
+
+	cnt := 0
+	err = New(func(info *WalkInfo) error {
+		require.False(t, info.IsMethod)
+		cnt++
+		return nil
+	}).Walk(val)
+	require.NoError(t, err)
+	require.Equal(t, 3, cnt)
+}
+
+func TestWalker_ForceInterfaceable(t *testing.T) {
+	type Inner struct {
+		Val int
+	}
+	type S struct {
+		priv  string
+		inner Inner
+	}
+	val := S{priv: "str", inner: Inner{Val: 1}}
+
+	t.Run("Default", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			// skip pointer and struct
+			if info.Parent != nil && info.Parent.Parent != nil {
+				require.False(t, info.Value.CanInterface())
+			}
+			return nil
+		}).Walk(&val))
+	})
+
+	t.Run("Force", func(t *testing.T) {
+		var visited []interface{}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			visited = append(visited, info.Value.Interface())
+			return nil
+		}).WithForceInterfaceable(true).Walk(&val))
+		require.Equal(t, []interface{}{&val, val, "str", Inner{Val: 1}, 1}, visited)
+	})
+
+	t.Run("Unaddressable", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Parent != nil {
+				require.False(t, info.Value.CanInterface())
+			}
+			return nil
+		}).WithForceInterfaceable(true).Walk(val))
+	})
+}
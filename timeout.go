@@ -0,0 +1,40 @@
+package objwalker
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTimeout mean walk stopped because of Walker.Timeout exceeded
+var ErrTimeout = errors.New("walk timeout")
+
+// WalkContext is same as Walk, but stop walk with ctx.Err() when ctx done.
+// Context checked before every callback, same as Walker.Timeout.
+func (w Walker) WalkContext(ctx context.Context, v interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	state := newWalkerState(w)
+	state.ctx = ctx
+	return state.walk(v, checkValue())
+}
+
+// walkDeadline return deadline of walk, started now, zero time for unlimited walk
+func walkDeadline(timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
+
+// checkInterrupt return ErrTimeout or context error if walk must be stopped
+func (state *walkerState) checkInterrupt() error {
+	if !state.deadline.IsZero() && time.Now().After(state.deadline) {
+		return ErrTimeout
+	}
+	if state.ctx != nil {
+		return state.ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package objwalker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_Timeout(t *testing.T) {
+	val := make([]int, 1000)
+
+	t.Run("Exceeded", func(t *testing.T) {
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			cnt++
+			time.Sleep(time.Millisecond)
+			return nil
+		}).WithTimeout(10 * time.Millisecond).Walk(val)
+		require.ErrorIs(t, err, ErrTimeout)
+		require.Less(t, cnt, len(val))
+	})
+
+	t.Run("FewSlowCallbacks", func(t *testing.T) {
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			cnt++
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}).WithTimeout(time.Millisecond).Walk(make([]int, 10))
+		require.ErrorIs(t, err, ErrTimeout)
+		require.LessOrEqual(t, cnt, 1)
+	})
+
+	t.Run("NotExceeded", func(t *testing.T) {
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			cnt++
+			return nil
+		}).WithTimeout(time.Minute).Walk(val)
+		require.NoError(t, err)
+		require.Equal(t, len(val)+1, cnt)
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cnt := 0
+		err := New(func(info *WalkInfo) error {
+			cnt++
+			if cnt == 10 {
+				cancel()
+			}
+			return nil
+		}).WithTimeout(time.Minute).WalkContext(ctx, val)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Less(t, cnt, len(val))
+
+		err = New(func(info *WalkInfo) error {
+			return nil
+		}).WalkContext(ctx, val)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("ContextAndTimeout", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		}).WithTimeout(10*time.Millisecond).WalkContext(context.Background(), val)
+		require.ErrorIs(t, err, ErrTimeout)
+	})
+}
@@ -0,0 +1,42 @@
+package objwalker
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrUnregisteredType mean walker found interface value with dynamic type, which isn't registered
+// in Walker.TypeRegistry
+var ErrUnregisteredType = errors.New("type is not registered")
+
+// TypeRegistry is set of named concrete types, expected as dynamic types of interface values.
+// Zero value is empty registry, ready to use. TypeRegistry isn't safe for register types concurrently with use.
+type TypeRegistry struct {
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+// Register add type t with the name to registry. It replace previous type with same name.
+func (r *TypeRegistry) Register(name string, t reflect.Type) {
+	if r.byName == nil {
+		r.byName = make(map[string]reflect.Type)
+		r.byType = make(map[reflect.Type]string)
+	}
+	if prev, ok := r.byName[name]; ok {
+		delete(r.byType, prev)
+	}
+	r.byName[name] = t
+	r.byType[t] = name
+}
+
+// Lookup return registered type by name, for example for use in Walker.InterfaceResolver
+func (r *TypeRegistry) Lookup(name string) (reflect.Type, bool) {
+	t, ok := r.byName[name]
+	return t, ok
+}
+
+// NameOf return name of registered type and true or empty string and false if the type isn't registered
+func (r *TypeRegistry) NameOf(t reflect.Type) (string, bool) {
+	name, ok := r.byType[t]
+	return name, ok
+}
@@ -0,0 +1,65 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeRegistry(t *testing.T) {
+	type Cat struct{ Name string }
+	type Dog struct{ Name string }
+	type Fish struct{ Name string }
+	type Zoo struct {
+		Animals []interface{}
+	}
+
+	var registry TypeRegistry
+	registry.Register("cat", reflect.TypeOf(Cat{}))
+	registry.Register("dog", reflect.TypeOf(Dog{}))
+
+	t.Run("Lookup", func(t *testing.T) {
+		catType, ok := registry.Lookup("cat")
+		require.True(t, ok)
+		require.Equal(t, reflect.TypeOf(Cat{}), catType)
+		_, ok = registry.Lookup("fish")
+		require.False(t, ok)
+
+		name, ok := registry.NameOf(reflect.TypeOf(Dog{}))
+		require.True(t, ok)
+		require.Equal(t, "dog", name)
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithTypeRegistry(&registry).Walk(Zoo{Animals: []interface{}{Cat{"c"}, Dog{"d"}, nil}})
+		require.NoError(t, err)
+	})
+
+	t.Run("Unregistered", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithTypeRegistry(&registry).Walk(Zoo{Animals: []interface{}{Cat{"c"}, Fish{"f"}}})
+		require.ErrorIs(t, err, ErrUnregisteredType)
+		require.Contains(t, err.Error(), ".Animals[1]")
+	})
+
+	t.Run("Resolver", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			return nil
+		}).WithInterfaceResolver(func(info *WalkInfo) (reflect.Value, bool) {
+			name, ok := info.Value.Elem().Interface().(string)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			t, ok := registry.Lookup(name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			return reflect.New(t).Elem(), true
+		}).WithTypeRegistry(&registry).Walk([]interface{}{"cat", "dog"})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+//go:build !go1.23
+
+package objwalker
+
+import "unsafe"
+
+// hchan repeat header of runtime channel, chan value is pointer to the struct.
+// It is hchan from runtime/chan.go of go1.20 - go1.22.
+//
+//nolint:unused,structcheck
+type hchan struct {
+	qcount   uint
+	dataqsiz uint
+	buf      unsafe.Pointer
+	elemsize uint16
+	closed   uint32
+	elemtype unsafe.Pointer
+	sendx    uint
+	recvx    uint
+	recvq    waitq
+	sendq    waitq
+	lock     uintptr
+}
@@ -0,0 +1,26 @@
+//go:build go1.23 && !go1.25
+
+package objwalker
+
+import "unsafe"
+
+// hchan repeat header of runtime channel, chan value is pointer to the struct.
+// It is hchan from runtime/chan.go of go1.23 and go1.24: go1.23 added timer field,
+// go1.24 added synctest flag to padding after elemsize.
+//
+//nolint:unused,structcheck
+type hchan struct {
+	qcount   uint
+	dataqsiz uint
+	buf      unsafe.Pointer
+	elemsize uint16
+	synctest bool
+	closed   uint32
+	timer    unsafe.Pointer
+	elemtype unsafe.Pointer
+	sendx    uint
+	recvx    uint
+	recvq    waitq
+	sendq    waitq
+	lock     uintptr
+}
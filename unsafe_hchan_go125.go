@@ -0,0 +1,25 @@
+//go:build go1.25
+
+package objwalker
+
+import "unsafe"
+
+// hchan repeat header of runtime channel, chan value is pointer to the struct.
+// It is hchan from runtime/chan.go of go1.25+: synctest flag of go1.24 replaced by bubble pointer before lock.
+//
+//nolint:unused,structcheck
+type hchan struct {
+	qcount   uint
+	dataqsiz uint
+	buf      unsafe.Pointer
+	elemsize uint16
+	closed   uint32
+	timer    unsafe.Pointer
+	elemtype unsafe.Pointer
+	sendx    uint
+	recvx    uint
+	recvq    waitq
+	sendq    waitq
+	bubble   unsafe.Pointer
+	lock     uintptr
+}
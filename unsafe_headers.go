@@ -0,0 +1,93 @@
+package objwalker
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// sliceHeader repeat runtime representation of slice
+type sliceHeader struct {
+	data unsafe.Pointer
+	len  int
+	cap  int
+}
+
+// stringHeader repeat runtime representation of string
+type stringHeader struct {
+	data unsafe.Pointer
+	len  int
+}
+
+// iface repeat runtime representation of non empty interface
+//
+//nolint:unused,structcheck
+type iface struct {
+	tab  unsafe.Pointer
+	data unsafe.Pointer
+}
+
+// MapInternals is read-only copy of runtime map header fields, see Walker.ExposeMapInternals.
+// Only Count is stable between go versions. B and Buckets are meaningful for classic runtime hash maps only:
+// go1.24+ use swiss table maps without buckets, B and Buckets are always zero there.
+type MapInternals struct {
+	// Count of map entries
+	Count int
+
+	// B is log2 of count of buckets, zero for swiss table maps
+	B uint8
+
+	// Buckets is pointer to array of buckets, nil for swiss table maps
+	Buckets unsafe.Pointer
+}
+
+// waitq repeat runtime list of goroutines, waited on channel
+//
+//nolint:unused,structcheck
+type waitq struct {
+	first unsafe.Pointer
+	last  unsafe.Pointer
+}
+
+// UnsafeHeader return pointer to runtime header of slice, string, map or chan value:
+// to slice or string header for slices and strings, to runtime map or channel struct for maps and chans.
+// It return nil for other kinds, values without DirectPointer and nil maps and chans.
+// Layout of the headers is internal detail of go runtime and may be changed in future go versions,
+// size of them can be get by SliceHeaderSize, StringHeaderSize, MapHeaderSize and ChanHeaderSize.
+func (w *WalkInfo) UnsafeHeader() unsafe.Pointer {
+	if !w.HasDirectPointer() {
+		return nil
+	}
+
+	//nolint:exhaustive
+	switch w.Value.Kind() {
+	case reflect.Slice, reflect.String:
+		return w.DirectPointer
+	case reflect.Map, reflect.Chan:
+		return *(*unsafe.Pointer)(w.DirectPointer)
+	default:
+		return nil
+	}
+}
+
+// SliceDataPtr return pointer to backing array of slice value, nil for other kinds.
+// Slices with same data pointer share backing array (or part of them).
+func (w *WalkInfo) SliceDataPtr() unsafe.Pointer {
+	if w.Value.Kind() != reflect.Slice {
+		return nil
+	}
+	if w.HasDirectPointer() {
+		return (*sliceHeader)(w.DirectPointer).data
+	}
+	return w.Value.UnsafePointer()
+}
+
+// InterfaceITab return first word of interface value: pointer to runtime itab for non empty interfaces
+// and pointer to runtime type of dynamic value for empty interfaces.
+// Values of same interface type with same dynamic type has same pointer.
+// It return nil for nil interfaces, other kinds and values without DirectPointer.
+func (w *WalkInfo) InterfaceITab() unsafe.Pointer {
+	if w.Value.Kind() != reflect.Interface || !w.HasDirectPointer() {
+		return nil
+	}
+	return (*iface)(w.DirectPointer).tab
+}
@@ -0,0 +1,185 @@
+package objwalker
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkInfo_UnsafeHeader(t *testing.T) {
+	type S struct {
+		Slice []int
+		Str   string
+		Map   map[int]int
+		Chan  chan int
+		NilCh chan int
+		Int   int
+	}
+	val := S{
+		Slice: make([]int, 2, 5),
+		Str:   "str",
+		Map:   map[int]int{1: 2, 3: 4},
+		Chan:  make(chan int, 3),
+	}
+
+	// header of every struct field in declaration order
+	var headers []unsafe.Pointer
+	require.NoError(t, New(func(info *WalkInfo) error {
+		if info.Parent != nil && info.Parent.Value.Kind() == reflect.Struct {
+			headers = append(headers, info.UnsafeHeader())
+		}
+		return nil
+	}).Walk(&val))
+	require.Len(t, headers, 6)
+
+	sliceHeaderPtr := (*sliceHeader)(headers[0])
+	require.Equal(t, unsafe.Pointer(&val.Slice[0]), sliceHeaderPtr.data)
+	require.Equal(t, 2, sliceHeaderPtr.len)
+	require.Equal(t, 5, sliceHeaderPtr.cap)
+
+	stringHeaderPtr := (*stringHeader)(headers[1])
+	require.Equal(t, 3, stringHeaderPtr.len)
+
+	require.Equal(t, reflect.ValueOf(val.Map).UnsafePointer(), headers[2])
+	require.Equal(t, 2, (*hmap)(headers[2]).mapCount())
+	require.Equal(t, reflect.ValueOf(val.Chan).UnsafePointer(), headers[3])
+	require.Equal(t, uint(3), (*hchan)(headers[3]).dataqsiz)
+	require.Zero(t, headers[4])
+	require.Zero(t, headers[5])
+
+	t.Run("ChanLayout", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		<-ch
+		close(ch)
+
+		h := (*hchan)(reflect.ValueOf(ch).UnsafePointer())
+		require.Equal(t, uint(1), h.qcount)
+		require.Equal(t, uint(3), h.dataqsiz)
+		require.Equal(t, uint16(unsafe.Sizeof(0)), h.elemsize)
+		require.NotZero(t, h.closed)
+		require.Equal(t, (*iface)(unsafe.Pointer(&[]reflect.Type{reflect.TypeOf(0)}[0])).data, h.elemtype)
+		require.Equal(t, uint(2), h.sendx)
+		require.Equal(t, uint(1), h.recvx)
+	})
+
+	t.Run("Unaddressable", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			require.Zero(t, info.UnsafeHeader())
+			return nil
+		}).Walk([]int{}))
+	})
+}
+
+func TestWalkInfo_SliceDataPtr(t *testing.T) {
+	backing := []int{1, 2, 3}
+	type S struct {
+		A []int
+		B []int
+		C []int
+	}
+
+	for _, test := range []struct {
+		name string
+		val  interface{}
+	}{
+		{"Addressable", &S{A: backing, B: backing[:2], C: []int{1, 2, 3}}},
+		{"Unaddressable", S{A: backing, B: backing[:2], C: []int{1, 2, 3}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var pointers []unsafe.Pointer
+			require.NoError(t, New(func(info *WalkInfo) error {
+				if info.Value.Kind() == reflect.Slice {
+					pointers = append(pointers, info.SliceDataPtr())
+				} else {
+					require.Zero(t, info.SliceDataPtr())
+				}
+				return nil
+			}).Walk(test.val))
+			require.Len(t, pointers, 3)
+			require.Equal(t, unsafe.Pointer(&backing[0]), pointers[0])
+			require.Equal(t, pointers[0], pointers[1])
+			require.NotEqual(t, pointers[0], pointers[2])
+		})
+	}
+}
+
+type testITabReader struct{}
+
+func (testITabReader) Read([]byte) (int, error) {
+	return 0, nil
+}
+
+func TestWalkInfo_InterfaceITab(t *testing.T) {
+	type S struct {
+		A   io.Reader
+		B   io.Reader
+		C   io.Reader
+		Nil io.Reader
+		Any interface{}
+	}
+	val := &S{A: testITabReader{}, B: testITabReader{}, C: &testITabReader{}, Any: 1}
+
+	tabs := map[string]unsafe.Pointer{}
+	err := New(func(info *WalkInfo) error {
+		if info.Value.Kind() == reflect.Interface {
+			tabs[info.StructField.Name] = info.InterfaceITab()
+		} else {
+			require.Zero(t, info.InterfaceITab())
+		}
+		return nil
+	}).Walk(val)
+	require.NoError(t, err)
+
+	require.NotZero(t, tabs["A"])
+	require.Equal(t, tabs["A"], tabs["B"])
+	require.NotZero(t, tabs["C"])
+	require.NotEqual(t, tabs["A"], tabs["C"])
+	require.Zero(t, tabs["Nil"])
+	require.NotZero(t, tabs["Any"])
+
+	t.Run("WithoutDirectPointer", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Interface {
+				require.Zero(t, info.InterfaceITab())
+			}
+			return nil
+		}).Walk(S{A: testITabReader{}})
+		require.NoError(t, err)
+	})
+}
+
+func TestWalker_ExposeMapInternals(t *testing.T) {
+	if unsafe.Sizeof(0) != 8 {
+		t.Skip("map header layout checked on 64 bit platforms only")
+	}
+
+	val := struct {
+		Map    map[int]string
+		NilMap map[int]string
+	}{Map: map[int]string{1: "a", 2: "b", 3: "c"}}
+
+	var internals []MapInternals
+	err := New(func(info *WalkInfo) error {
+		if info.IsMapInternals {
+			require.Equal(t, reflect.Map, info.Parent.Value.Kind())
+			internals = append(internals, info.Value.Interface().(MapInternals))
+		}
+		return nil
+	}).WithExposeMapInternals(true).Walk(&val)
+	require.NoError(t, err)
+	require.Len(t, internals, 1)
+	require.Equal(t, len(val.Map), internals[0].Count)
+
+	t.Run("Unaddressable", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			require.False(t, info.IsMapInternals)
+			return nil
+		}).WithExposeMapInternals(true).Walk(map[int]int{1: 2})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,32 @@
+//go:build !go1.24 || (!go1.26 && !goexperiment.swissmap)
+
+package objwalker
+
+import "unsafe"
+
+// hmap repeat header of classic runtime hash map, map value is pointer to the struct.
+// It is hmap from runtime/map.go of go1.20 - go1.23, go1.24 and go1.25 use it with GOEXPERIMENT=noswissmap only.
+//
+//nolint:unused,structcheck
+type hmap struct {
+	count      int
+	flags      uint8
+	B          uint8
+	noverflow  uint16
+	hash0      uint32
+	buckets    unsafe.Pointer
+	oldbuckets unsafe.Pointer
+	nevacuate  uintptr
+	extra      unsafe.Pointer
+}
+
+// mapCount return count of entries from map header
+func (h *hmap) mapCount() int {
+	return h.count
+}
+
+// newMapInternals return copy of fields of map header
+func newMapInternals(header unsafe.Pointer) MapInternals {
+	h := (*hmap)(header)
+	return MapInternals{Count: h.count, B: h.B, Buckets: h.buckets}
+}
@@ -0,0 +1,35 @@
+//go:build go1.24 && (go1.26 || goexperiment.swissmap)
+
+package objwalker
+
+import "unsafe"
+
+// hmap repeat header of swiss table runtime map, map value is pointer to the struct.
+// It is Map from internal/runtime/maps/map.go of go1.24+, default map implementation since go1.24
+// and the only one since go1.26. The tombstonePossible flag was added to padding after writing,
+// it doesn't change offsets of other fields.
+//
+//nolint:unused,structcheck
+type hmap struct {
+	used              uint64
+	seed              uintptr
+	dirPtr            unsafe.Pointer
+	dirLen            int
+	globalDepth       uint8
+	globalShift       uint8
+	writing           uint8
+	tombstonePossible bool
+	clearSeq          uint64
+}
+
+// mapCount return count of entries from map header
+func (h *hmap) mapCount() int {
+	return int(h.used)
+}
+
+// newMapInternals return copy of fields of map header
+// swiss table has no buckets, so B and Buckets left zero
+func newMapInternals(header unsafe.Pointer) MapInternals {
+	h := (*hmap)(header)
+	return MapInternals{Count: h.mapCount()}
+}
@@ -0,0 +1,35 @@
+//go:build go1.24 && (go1.26 || goexperiment.swissmap)
+
+package objwalker
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapHeaderSize_Swiss(t *testing.T) {
+	if unsafe.Sizeof(uintptr(0)) != 8 {
+		t.Skip("size checked for 64 bit platforms only")
+	}
+	// internal/runtime/maps.Map is 48 bytes on 64 bit platforms
+	require.Equal(t, 48, MapHeaderSize())
+}
+
+func TestWalker_ExposeMapInternals_Swiss(t *testing.T) {
+	m := make(map[int]int, 100)
+	for i := 0; i < 100; i++ {
+		m[i] = i
+	}
+
+	var internals []MapInternals
+	err := New(func(info *WalkInfo) error {
+		if info.IsMapInternals {
+			internals = append(internals, info.Value.Interface().(MapInternals))
+		}
+		return nil
+	}).WithExposeMapInternals(true).Walk(&m)
+	require.NoError(t, err)
+	require.Equal(t, []MapInternals{{Count: len(m)}}, internals)
+}
@@ -0,0 +1,130 @@
+package objwalker
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// maxInt is max value of int on build platform: 32 or 64 bit
+const maxInt = int(^uint(0) >> 1)
+
+// sizeToInt convert size in bytes to int.
+// It return maxInt for sizes, which doesn't fit int (possible on 32-bit platforms), instead of negative value.
+func sizeToInt(size uintptr) int {
+	if size > uintptr(maxInt) {
+		return maxInt
+	}
+	return int(size)
+}
+
+// addSizes return sum of non negative sizes, it return maxInt instead of overflow
+func addSizes(a, b int) int {
+	if a > maxInt-b {
+		return maxInt
+	}
+	return a + b
+}
+
+func sliceSize() int {
+	return sizeToInt(unsafe.Sizeof(sliceHeader{}))
+}
+
+func stringSize() int {
+	return sizeToInt(unsafe.Sizeof(stringHeader{}))
+}
+
+func mapSize() int {
+	return sizeToInt(unsafe.Sizeof(hmap{}))
+}
+
+func interfaceSize() int {
+	return sizeToInt(unsafe.Sizeof(iface{}))
+}
+
+func chanStructSize() int {
+	return sizeToInt(unsafe.Sizeof(hchan{}))
+}
+
+// SliceHeaderSize return size of runtime slice header in bytes
+func SliceHeaderSize() int {
+	return sliceSize()
+}
+
+// StringHeaderSize return size of runtime string header in bytes
+func StringHeaderSize() int {
+	return stringSize()
+}
+
+// MapHeaderSize return size of runtime map struct in bytes, map value is pointer to the struct
+func MapHeaderSize() int {
+	return mapSize()
+}
+
+// InterfaceSize return size of interface value in bytes
+func InterfaceSize() int {
+	return interfaceSize()
+}
+
+// ChanHeaderSize return size of runtime channel struct in bytes, chan value is pointer to the struct
+func ChanHeaderSize() int {
+	return chanStructSize()
+}
+
+// valueSize return count of bytes, owned by value itself, without children.
+// Struct and array data fully owned by fields/items, so them size is zero.
+func valueSize(v reflect.Value) int {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Array, reflect.Struct:
+		return 0
+	case reflect.Slice:
+		return sliceSize()
+	case reflect.String:
+		return stringSize()
+	case reflect.Interface:
+		return interfaceSize()
+	case reflect.Map:
+		return addSizes(sizeToInt(v.Type().Size()), mapSize())
+	case reflect.Chan:
+		return addSizes(sizeToInt(v.Type().Size()), chanStructSize())
+	default:
+		return sizeToInt(v.Type().Size())
+	}
+}
+
+// ShallowSize return approximate count of bytes of value without referenced data:
+// size of value type and size of runtime map or channel struct for non nil maps and chans.
+func (w *WalkInfo) ShallowSize() uintptr {
+	size := w.Value.Type().Size()
+	//nolint:exhaustive
+	switch w.Value.Kind() {
+	case reflect.Map:
+		if !w.Value.IsNil() {
+			size += unsafe.Sizeof(hmap{})
+		}
+	case reflect.Chan:
+		if !w.Value.IsNil() {
+			size += unsafe.Sizeof(hchan{})
+		}
+	}
+	return size
+}
+
+// DeepSizeHint return ShallowSize with size of direct data of value: items of slice, bytes of string,
+// keys and values of map entries and buffer of channel. Data, referenced by items, doesn't counted.
+func (w *WalkInfo) DeepSizeHint() uintptr {
+	size := w.ShallowSize()
+	v := w.Value
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Slice:
+		size += uintptr(v.Len()) * v.Type().Elem().Size()
+	case reflect.String:
+		size += uintptr(v.Len())
+	case reflect.Map:
+		size += uintptr(v.Len()) * (v.Type().Key().Size() + v.Type().Elem().Size())
+	case reflect.Chan:
+		size += uintptr(v.Cap()) * v.Type().Elem().Size()
+	}
+	return size
+}
@@ -0,0 +1,104 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportedSizes(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		exported int
+		internal int
+	}{
+		{"Slice", SliceHeaderSize(), sliceSize()},
+		{"String", StringHeaderSize(), stringSize()},
+		{"Map", MapHeaderSize(), mapSize()},
+		{"Interface", InterfaceSize(), interfaceSize()},
+		{"Chan", ChanHeaderSize(), chanStructSize()},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.NotZero(t, test.exported)
+			require.Equal(t, test.internal, test.exported)
+		})
+	}
+}
+
+func TestChanHeaderSize(t *testing.T) {
+	// runtime allocate buffer of channel with pointer free elements right after header, aligned to 8 bytes
+	const maxAlign = 8
+	ch := make(chan int, 1)
+	header := reflect.ValueOf(ch).UnsafePointer()
+	bufOffset := uintptr((*hchan)(header).buf) - uintptr(header)
+	require.Equal(t, (ChanHeaderSize()+maxAlign-1)&^(maxAlign-1), int(bufOffset))
+}
+
+func TestSizesPointerAligned(t *testing.T) {
+	ptrAlign := int(unsafe.Alignof(uintptr(0)))
+	ptrSize := int(unsafe.Sizeof(uintptr(0)))
+	for _, test := range []struct {
+		name string
+		size int
+	}{
+		{"Slice", SliceHeaderSize()},
+		{"String", StringHeaderSize()},
+		{"Map", MapHeaderSize()},
+		{"Interface", InterfaceSize()},
+		{"Chan", ChanHeaderSize()},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Positive(t, test.size)
+			require.Zero(t, test.size%ptrAlign)
+		})
+	}
+
+	require.Equal(t, 3*ptrSize, SliceHeaderSize())
+	require.Equal(t, 2*ptrSize, StringHeaderSize())
+	require.Equal(t, 2*ptrSize, InterfaceSize())
+}
+
+func TestSizeOverflow(t *testing.T) {
+	require.Equal(t, 10, sizeToInt(10))
+	require.Equal(t, maxInt, sizeToInt(^uintptr(0)))
+	require.Equal(t, 5, addSizes(2, 3))
+	require.Equal(t, maxInt, addSizes(maxInt-1, 2))
+	require.Equal(t, maxInt, addSizes(maxInt, maxInt))
+}
+
+func TestWalkInfo_SizeHints(t *testing.T) {
+	sizes := map[string][2]uintptr{}
+	val := struct {
+		Slice []int32
+		Str   string
+		Map   map[int32]int64
+		Chan  chan int16
+		Num   int64
+		Arr   [3]int16
+	}{
+		Slice: []int32{1, 2, 3, 4},
+		Str:   "abc",
+		Map:   map[int32]int64{1: 2},
+		Chan:  make(chan int16, 5),
+	}
+	err := New(func(info *WalkInfo) error {
+		if info.StructField != nil {
+			sizes[info.StructField.Name] = [2]uintptr{info.ShallowSize(), info.DeepSizeHint()}
+		}
+		return nil
+	}).Walk(val)
+	require.NoError(t, err)
+
+	sliceHeader := uintptr(SliceHeaderSize())
+	require.Equal(t, [2]uintptr{sliceHeader, sliceHeader + 16}, sizes["Slice"])
+	stringHeader := uintptr(StringHeaderSize())
+	require.Equal(t, [2]uintptr{stringHeader, stringHeader + 3}, sizes["Str"])
+	mapShallow := unsafe.Sizeof(uintptr(0)) + uintptr(MapHeaderSize())
+	require.Equal(t, [2]uintptr{mapShallow, mapShallow + 12}, sizes["Map"])
+	chanShallow := unsafe.Sizeof(uintptr(0)) + uintptr(ChanHeaderSize())
+	require.Equal(t, [2]uintptr{chanShallow, chanShallow + 10}, sizes["Chan"])
+	require.Equal(t, [2]uintptr{8, 8}, sizes["Num"])
+	require.Equal(t, [2]uintptr{6, 6}, sizes["Arr"])
+}
@@ -0,0 +1,53 @@
+package objwalker
+
+import "reflect"
+
+type singleUnwrapper interface {
+	Unwrap() error
+}
+
+type multiUnwrapper interface {
+	Unwrap() []error
+}
+
+var (
+	singleUnwrapperType = reflect.TypeOf((*singleUnwrapper)(nil)).Elem()
+	multiUnwrapperType  = reflect.TypeOf((*multiUnwrapper)(nil)).Elem()
+)
+
+// asErrorUnwrapper return function, which return wrapped errors of value, if value can unwrap errors.
+// Pointers and interfaces handled by they elements, it allow loop protection detect cycles in error chains.
+func asErrorUnwrapper(v reflect.Value) (func() []interface{}, bool) {
+	if kind := v.Kind(); kind == reflect.Pointer || kind == reflect.Interface {
+		return nil, false
+	}
+
+	var unwrapper interface{}
+	switch {
+	case v.CanInterface() && (v.Type().Implements(singleUnwrapperType) || v.Type().Implements(multiUnwrapperType)):
+		unwrapper = v.Interface()
+	case v.CanAddr() && v.Addr().CanInterface() &&
+		(v.Addr().Type().Implements(singleUnwrapperType) || v.Addr().Type().Implements(multiUnwrapperType)):
+		unwrapper = v.Addr().Interface()
+	default:
+		return nil, false
+	}
+
+	return func() []interface{} {
+		var errs []error
+		switch u := unwrapper.(type) {
+		case singleUnwrapper:
+			errs = []error{u.Unwrap()}
+		case multiUnwrapper:
+			errs = u.Unwrap()
+		}
+
+		res := make([]interface{}, len(errs))
+		for i, err := range errs {
+			if err != nil {
+				res[i] = err
+			}
+		}
+		return res
+	}, true
+}
@@ -0,0 +1,63 @@
+package objwalker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type loopError struct {
+	next *loopError
+}
+
+func (e *loopError) Error() string {
+	return "loop"
+}
+
+func (e *loopError) Unwrap() error {
+	return e.next
+}
+
+func TestWalker_UnwrapErrors(t *testing.T) {
+	walkErrors := func(w *Walker, v interface{}) []string {
+		return walkCollect(t, w, v, func(info *WalkInfo) (string, bool) {
+			if info.Value.Kind() != reflect.Pointer || !info.Value.CanInterface() {
+				return "", false
+			}
+			err, ok := info.Value.Interface().(error)
+			if !ok {
+				return "", false
+			}
+			return err.Error(), true
+		})
+	}
+
+	base := errors.New("base")
+
+	t.Run("Chain", func(t *testing.T) {
+		err := fmt.Errorf("l3: %w", fmt.Errorf("l2: %w", base))
+		require.Equal(t, []string{"l3: l2: base", "l2: base", "base"},
+			walkErrors(New(nil).WithUnwrapErrors(true), err))
+	})
+
+	t.Run("Join", func(t *testing.T) {
+		err := errors.Join(fmt.Errorf("a: %w", base), errors.New("b"))
+		require.Equal(t, []string{"a: base\nb", "a: base", "base", "b"},
+			walkErrors(New(nil).WithUnwrapErrors(true), err))
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		err := &loopError{}
+		err.next = err
+		// root pointer and unwrapped pointer, pointed struct visited once
+		require.Equal(t, []string{"loop", "loop"}, walkErrors(New(nil).WithUnwrapErrors(true), err))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		err := fmt.Errorf("l2: %w", base)
+		require.Equal(t, []string{"l2: base"}, walkErrors(New(nil), err))
+	})
+}
@@ -0,0 +1,92 @@
+package objwalker
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// valueHashBudget limit count of values, read for hash one value
+const valueHashBudget = 1024
+
+// valueHash calculate hash of value content for loop detection of unaddressable values.
+// Pointer-like values (pointers, maps, slices, ...) hashed by address of data, without read pointed data.
+// ok is false if value too big for hash
+func valueHash(v reflect.Value) (res uint64, ok bool) {
+	h := valueHasher{hash: fnv.New64a(), budget: valueHashBudget}
+	if !h.write(v) {
+		return 0, false
+	}
+	return h.hash.Sum64(), true
+}
+
+type valueHasher struct {
+	hash   hash.Hash64
+	budget int
+	buf    [8]byte
+}
+
+func (h *valueHasher) writeUint(v uint64) {
+	binary.LittleEndian.PutUint64(h.buf[:], v)
+	_, _ = h.hash.Write(h.buf[:])
+}
+
+//nolint:cyclop
+func (h *valueHasher) write(v reflect.Value) bool {
+	h.budget--
+	if h.budget < 0 {
+		return false
+	}
+
+	h.writeUint(uint64(v.Kind()))
+	if !v.IsValid() {
+		return true
+	}
+	h.writeUint(uint64(reflect.ValueOf(v.Type()).Pointer()))
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.writeUint(1)
+		} else {
+			h.writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.writeUint(math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		h.writeUint(math.Float64bits(real(c)))
+		h.writeUint(math.Float64bits(imag(c)))
+	case reflect.String:
+		h.writeUint(uint64(v.Len()))
+		_, _ = h.hash.Write([]byte(v.String()))
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.UnsafePointer:
+		h.writeUint(uint64(v.Pointer()))
+	case reflect.Slice:
+		h.writeUint(uint64(v.Pointer()))
+		h.writeUint(uint64(v.Len()))
+	case reflect.Interface:
+		return h.write(v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !h.write(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !h.write(v.Field(i)) {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueHash(t *testing.T) {
+	type S struct {
+		I int
+		s string
+		P *int
+	}
+
+	vInt := 1
+	hash := func(v interface{}) uint64 {
+		res, ok := valueHash(reflect.ValueOf(v))
+		require.True(t, ok)
+		return res
+	}
+
+	require.Equal(t, hash(S{I: 1, s: "a", P: &vInt}), hash(S{I: 1, s: "a", P: &vInt}))
+	require.NotEqual(t, hash(S{I: 1, s: "a"}), hash(S{I: 1, s: "b"}))
+	require.NotEqual(t, hash(S{I: 1, P: &vInt}), hash(S{I: 1, P: new(int)}))
+	require.NotEqual(t, hash(int32(1)), hash(int64(1)))
+
+	_, ok := valueHash(reflect.ValueOf(make([]int, valueHashBudget)))
+	require.True(t, ok)
+	_, ok = valueHash(reflect.ValueOf([valueHashBudget]int{}))
+	require.False(t, ok)
+}
+
+func TestWalker_ValueHashLoopProtection(t *testing.T) {
+	m := map[string]interface{}{}
+	m["self"] = m
+
+	callTimes := 0
+	require.NoError(t, New(func(info *WalkInfo) error {
+		callTimes++
+		return nil
+	}).WithValueHashLoopProtection(true).Walk(m))
+
+	// map, key, interface value, but not the map again
+	require.Equal(t, 3, callTimes)
+
+	t.Run("EqualValues", func(t *testing.T) {
+		type Item struct {
+			X int
+		}
+		var paths []string
+		err := New(func(info *WalkInfo) error {
+			paths = append(paths, info.Path())
+			return nil
+		}).WithMapKeyVisit(MapValueOnly).WithValueHashLoopProtection(true).
+			Walk(map[string]Item{"a": {X: 1}, "b": {X: 1}})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"", "[a]", "[a].X", "[b]", "[b].X"}, paths)
+	})
+}
@@ -0,0 +1,42 @@
+package objwalker
+
+import "reflect"
+
+// Visitor is alternative to WalkFunc callback, see NewVisitor
+type Visitor interface {
+	// Visit called for every walked value, same as WalkFunc
+	Visit(info *WalkInfo) error
+}
+
+// StructVisitor is optional interface of Visitor, VisitStruct called instead of Visit for struct values
+type StructVisitor interface {
+	VisitStruct(info *WalkInfo) error
+}
+
+// LeafVisitor is optional interface of Visitor, VisitLeaf called instead of Visit for values,
+// which can't have children: not structs, arrays, slices, maps, pointers and interfaces
+type LeafVisitor interface {
+	VisitLeaf(info *WalkInfo) error
+}
+
+// NewVisitor create walker with default options, which call methods of visitor for walked values
+func NewVisitor(visitor Visitor) *Walker {
+	return New(visitorFunc(visitor))
+}
+
+// visitorFunc adapt visitor to WalkFunc
+func visitorFunc(visitor Visitor) WalkFunc {
+	structVisitor, _ := visitor.(StructVisitor)
+	leafVisitor, _ := visitor.(LeafVisitor)
+	return func(info *WalkInfo) error {
+		kind := info.Value.Kind()
+		switch {
+		case structVisitor != nil && kind == reflect.Struct:
+			return structVisitor.VisitStruct(info)
+		case leafVisitor != nil && !isComposite(kind):
+			return leafVisitor.VisitLeaf(info)
+		default:
+			return visitor.Visit(info)
+		}
+	}
+}
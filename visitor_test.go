@@ -0,0 +1,69 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testPathVisitor struct {
+	paths []string
+}
+
+func (v *testPathVisitor) Visit(info *WalkInfo) error {
+	v.paths = append(v.paths, info.Path()+":"+info.Value.Kind().String())
+	return nil
+}
+
+type testRichVisitor struct {
+	testPathVisitor
+	structs []string
+	leaves  []string
+}
+
+func (v *testRichVisitor) VisitStruct(info *WalkInfo) error {
+	v.structs = append(v.structs, info.Path())
+	return nil
+}
+
+func (v *testRichVisitor) VisitLeaf(info *WalkInfo) error {
+	v.leaves = append(v.leaves, info.Path())
+	if info.Value.Kind() == reflect.String {
+		return ErrStop
+	}
+	return nil
+}
+
+func TestNewVisitor(t *testing.T) {
+	type Inner struct {
+		A int
+	}
+	type S struct {
+		Inner Inner
+		Items []int
+		Ptr   *Inner
+		Name  string
+	}
+	val := S{Inner: Inner{A: 1}, Items: []int{2, 3}, Ptr: &Inner{A: 4}, Name: "name"}
+
+	t.Run("Visit", func(t *testing.T) {
+		var expected []string
+		require.NoError(t, New(func(info *WalkInfo) error {
+			expected = append(expected, info.Path()+":"+info.Value.Kind().String())
+			return nil
+		}).Walk(val))
+
+		visitor := &testPathVisitor{}
+		require.NoError(t, NewVisitor(visitor).Walk(val))
+		require.Equal(t, expected, visitor.paths)
+	})
+
+	t.Run("Rich", func(t *testing.T) {
+		visitor := &testRichVisitor{}
+		require.NoError(t, NewVisitor(visitor).Walk(val))
+		require.Equal(t, []string{"", ".Inner", ".Ptr"}, visitor.structs)
+		require.Equal(t, []string{".Inner.A", ".Items[0]", ".Items[1]", ".Ptr.A", ".Name"}, visitor.leaves)
+		require.Equal(t, []string{".Items:slice", ".Ptr:ptr"}, visitor.paths)
+	})
+}
@@ -0,0 +1,146 @@
+package objwalker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrPathNotFound mean value with the path doesn't exist in walked object
+var ErrPathNotFound = errors.New("path not found")
+
+// WalkAt find value of v by path in format of WalkInfo.Path and walk over the value only.
+// Callback doesn't called for values before the value, but WalkInfo.Parent and WalkInfo.Path
+// of walked values describe position from root of v.
+// Map keys in path compared with text of keys, same as in WalkInfo.Path, if many keys has same text - any of them can be used.
+// Root value prepared same as in Walker.Walk: for example with Walker.Snapshot WalkAt walk over copy of v.
+// WalkAt return ErrPathNotFound if path has no value in v.
+func (w Walker) WalkAt(v interface{}, path string) error {
+	state := newWalkerState(w)
+	if state.UnsafeReadDirectPtr && !checkValue() {
+		return ErrBadInternalReflectValueDetected
+	}
+
+	rv, ok, err := state.rootValue(v)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if path == "" {
+			return nil
+		}
+		return fmt.Errorf("path %q in nil value: %w", path, ErrPathNotFound)
+	}
+
+	info, err := state.findPath(state.newWalkerInfo(rv, nil), path)
+	if err != nil {
+		return err
+	}
+	return state.walkRootInfo(info)
+}
+
+// findPath return info of value at path from info
+func (state *walkerState) findPath(info *WalkInfo, path string) (*WalkInfo, error) {
+	fullPath := path
+	for path != "" {
+		// pointers and interfaces has no own path segments
+		for kind := info.Value.Kind(); kind == reflect.Pointer || kind == reflect.Interface; kind = info.Value.Kind() {
+			if info.Value.IsNil() {
+				return nil, fmt.Errorf("nil value before %q of path %q: %w", path, fullPath, ErrPathNotFound)
+			}
+			info = state.newWalkerInfo(info.Value.Elem(), info)
+		}
+
+		var next *WalkInfo
+		var rest string
+		switch {
+		case path[0] == '.' && info.Value.Kind() == reflect.Struct:
+			next, rest = state.findField(info, path)
+		case path[0] == '[' && (info.Value.Kind() == reflect.Slice || info.Value.Kind() == reflect.Array):
+			next, rest = state.findItem(info, path)
+		case (path[0] == '[' || path[0] == '{') && info.Value.Kind() == reflect.Map:
+			next, rest = state.findMapEntry(info, path)
+		}
+		if next == nil {
+			return nil, fmt.Errorf("segment %q of path %q: %w", path, fullPath, ErrPathNotFound)
+		}
+		info, path = next, rest
+	}
+	return info, nil
+}
+
+func (state *walkerState) findField(info *WalkInfo, path string) (*WalkInfo, string) {
+	name := path[1:]
+	if end := strings.IndexAny(name, ".[{"); end >= 0 {
+		name = name[:end]
+	}
+
+	t := info.Value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name != name {
+			continue
+		}
+		fieldInfo := state.newWalkerInfo(info.Value.Field(i), info)
+		fieldInfo.StructField = &field
+		fieldInfo.SiblingIndex = i
+		fieldInfo.SiblingCount = t.NumField()
+		return fieldInfo, path[len(name)+1:]
+	}
+	return nil, path
+}
+
+func (state *walkerState) findItem(info *WalkInfo, path string) (*WalkInfo, string) {
+	end := strings.IndexByte(path, ']')
+	if end < 0 {
+		return nil, path
+	}
+	index, err := strconv.Atoi(path[1:end])
+	if err != nil || index < 0 || index >= info.Value.Len() {
+		return nil, path
+	}
+
+	itemInfo := state.newWalkerInfo(info.Value.Index(index), info)
+	itemInfo.Index = index
+	itemInfo.SiblingIndex = index
+	itemInfo.SiblingCount = info.Value.Len()
+	return itemInfo, path[end+1:]
+}
+
+// findMapEntry find map key for {key} segment and map value for [key] segment.
+// It use longest matched key, because text of key can contain path delimiters.
+func (state *walkerState) findMapEntry(info *WalkInfo, path string) (*WalkInfo, string) {
+	isKey := path[0] == '{'
+	closeBracket := "]"
+	if isKey {
+		closeBracket = "}"
+	}
+
+	var found, foundValue reflect.Value
+	var foundSegment string
+	iterator := info.Value.MapRange()
+	for iterator.Next() {
+		segment := path[:1] + formatMapKey(state.KeyFormatter, iterator.Key()) + closeBracket
+		if strings.HasPrefix(path, segment) && len(segment) > len(foundSegment) {
+			found = iterator.Key()
+			foundValue = iterator.Value()
+			foundSegment = segment
+		}
+	}
+	if !found.IsValid() {
+		return nil, path
+	}
+
+	var entryInfo *WalkInfo
+	if isKey {
+		entryInfo = state.newWalkerInfo(found, info)
+		entryInfo.isMapKey = true
+	} else {
+		entryInfo = state.newWalkerInfo(foundValue, info)
+		entryInfo.isMapValue = true
+	}
+	entryInfo.mapKey = found
+	return entryInfo, path[len(foundSegment):]
+}
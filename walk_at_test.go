@@ -0,0 +1,82 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalker_WalkAt(t *testing.T) {
+	type Item struct {
+		Name string
+	}
+	type S struct {
+		Field []*Item
+		Map   map[string]Item
+		Ints  [2]int
+	}
+	val := &S{
+		Field: []*Item{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}},
+		Map:   map[string]Item{"k": {Name: "m"}, "k]": {Name: "n"}},
+		Ints:  [2]int{1, 2},
+	}
+
+	walkPaths := func(path string) ([]string, error) {
+		var paths []string
+		err := New(func(info *WalkInfo) error {
+			paths = append(paths, info.Path())
+			return nil
+		}).WalkAt(val, path)
+		return paths, err
+	}
+
+	for _, test := range []struct {
+		path  string
+		paths []string
+	}{
+		{".Field[2]", []string{".Field[2]", ".Field[2]", ".Field[2].Name"}},
+		{".Field[3].Name", []string{".Field[3].Name"}},
+		{".Map[k]", []string{".Map[k]", ".Map[k].Name"}},
+		{".Map[k]].Name", []string{".Map[k]].Name"}},
+		{".Map{k}", []string{".Map{k}"}},
+		{".Ints[1]", []string{".Ints[1]"}},
+		{".Ints", []string{".Ints", ".Ints[0]", ".Ints[1]"}},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			paths, err := walkPaths(test.path)
+			require.NoError(t, err)
+			require.Equal(t, test.paths, paths)
+		})
+	}
+
+	t.Run("Root", func(t *testing.T) {
+		paths, err := walkPaths("")
+		require.NoError(t, err)
+		require.Len(t, paths, 25)
+	})
+
+	for _, path := range []string{".Unknown", ".Field[4]", ".Field[x]", ".Map[x]", "[0]", ".Ints.Name", "Field"} {
+		t.Run("NotFound"+path, func(t *testing.T) {
+			paths, err := walkPaths(path)
+			require.ErrorIs(t, err, ErrPathNotFound)
+			require.Empty(t, paths)
+		})
+	}
+
+	t.Run("Snapshot", func(t *testing.T) {
+		err := New(func(info *WalkInfo) error {
+			require.NoError(t, info.SetString("changed"))
+			return nil
+		}).WithSnapshot(true).WalkAt(val, ".Field[0].Name")
+		require.NoError(t, err)
+		require.Equal(t, "a", val.Field[0].Name)
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		require.NoError(t, New(func(info *WalkInfo) error {
+			return errTest
+		}).WalkAt(reflect.Value{}, ""))
+		require.ErrorIs(t, New(nil).WalkAt(reflect.Value{}, ".Name"), ErrPathNotFound)
+	})
+}
@@ -0,0 +1,50 @@
+package objwalker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkWalker_WalkInfoPooling(b *testing.B) {
+	val := make([][]int, 100)
+	for i := range val {
+		val[i] = make([]int, 100)
+	}
+
+	for _, pooling := range []bool{false, true} {
+		b.Run(fmt.Sprint(pooling), func(b *testing.B) {
+			walker := New(func(info *WalkInfo) error {
+				return nil
+			}).WithWalkInfoPooling(pooling)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = walker.Walk(val)
+			}
+		})
+	}
+}
+
+func TestWalker_WalkInfoPooling(t *testing.T) {
+	type Item struct {
+		Name string
+		Tags []string
+		Sub  *Item
+	}
+	val := []Item{
+		{Name: "a", Tags: []string{"x"}, Sub: &Item{Name: "b"}},
+		{Name: "c", Tags: []string{"y", "z"}},
+	}
+
+	walkNodes := func(w *Walker) []string {
+		return walkCollect(t, w, val, func(info *WalkInfo) (string, bool) {
+			return fmt.Sprintf("%v:%v:%v", info.Path(), info.Value.Kind(), info.IsVisited), true
+		})
+	}
+
+	expected := walkNodes(New(nil))
+	for i := 0; i < 3; i++ {
+		require.Equal(t, expected, walkNodes(New(nil).WithWalkInfoPooling(true)))
+	}
+}
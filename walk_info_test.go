@@ -0,0 +1,474 @@
+package objwalker
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkInfo_IsNestedContainer(t *testing.T) {
+	val := map[string]map[string]int{"a": {"b": 1}}
+	nested := map[string]bool{}
+	require.NoError(t, New(func(info *WalkInfo) error {
+		nested[info.Path()] = info.IsNestedContainer()
+		return nil
+	}).Walk(val))
+	require.Equal(t, map[string]bool{
+		"":       false,
+		"{a}":    false,
+		"[a]":    true,
+		"[a]{b}": false,
+		"[a][b]": false,
+	}, nested)
+
+	t.Run("Slice", func(t *testing.T) {
+		nested := map[string]bool{}
+		require.NoError(t, New(func(info *WalkInfo) error {
+			nested[info.Path()] = info.IsNestedContainer()
+			return nil
+		}).Walk([][]int{{1}}))
+		require.Equal(t, map[string]bool{"": false, "[0]": true, "[0][0]": false}, nested)
+	})
+}
+
+func TestWalker_Cap(t *testing.T) {
+	t.Run("Slice", func(t *testing.T) {
+		val := make([]int, 2, 8)
+		wasSlice := false
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Slice {
+				wasSlice = true
+				require.Equal(t, 8, info.Cap)
+			} else {
+				require.Equal(t, -1, info.Cap)
+			}
+			return nil
+		}).Walk(val))
+		require.True(t, wasSlice)
+	})
+	t.Run("Array", func(t *testing.T) {
+		val := [3]int{}
+		wasArray := false
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Array {
+				wasArray = true
+				require.Equal(t, 3, info.Cap)
+			}
+			return nil
+		}).Walk(val))
+		require.True(t, wasArray)
+	})
+}
+
+func TestWalker_ArrayLen(t *testing.T) {
+	type S struct {
+		Arr   [4]int
+		Slice []int
+	}
+	arrayLens := map[string]int{}
+	err := New(func(info *WalkInfo) error {
+		arrayLens[info.Path()] = info.ArrayLen
+		return nil
+	}).Walk(S{Slice: make([]int, 1, 4)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		"":          -1,
+		".Arr":      4,
+		".Arr[0]":   -1,
+		".Arr[1]":   -1,
+		".Arr[2]":   -1,
+		".Arr[3]":   -1,
+		".Slice":    -1,
+		".Slice[0]": -1,
+	}, arrayLens)
+}
+
+func TestWalker_MapLen(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		val  map[string]int
+		len  int
+	}{
+		{"Map", map[string]int{"a": 1, "b": 2}, 2},
+		{"Nil", nil, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			wasMap := false
+			require.NoError(t, New(func(info *WalkInfo) error {
+				if info.Value.Kind() == reflect.Map {
+					wasMap = true
+					require.Equal(t, test.len, info.MapLen)
+				} else {
+					require.Equal(t, -1, info.MapLen)
+				}
+				return nil
+			}).Walk(test.val))
+			require.True(t, wasMap)
+		})
+	}
+}
+
+func TestWalker_NamedType(t *testing.T) {
+	type Celsius float64
+	type S struct {
+		Temp Celsius
+		Raw  float64
+	}
+	val := S{Temp: 1, Raw: 2}
+
+	t.Run("TypeName", func(t *testing.T) {
+		var names []string
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Struct {
+				require.Equal(t, "S", info.TypeName())
+			}
+			if info.Value.Kind() == reflect.Float64 {
+				names = append(names, info.TypeName())
+			}
+			return nil
+		}).Walk(val))
+		require.Equal(t, []string{"Celsius", "float64"}, names)
+
+		require.NoError(t, New(func(info *WalkInfo) error {
+			require.Empty(t, info.TypeName())
+			return nil
+		}).Walk([]struct{}{{}}))
+	})
+
+	t.Run("Hook", func(t *testing.T) {
+		var hooked []reflect.Type
+		require.NoError(t, New(func(info *WalkInfo) error {
+			return nil
+		}).WithNamedTypeHook(func(info *WalkInfo) error {
+			hooked = append(hooked, info.Value.Type())
+			return nil
+		}).Walk(val))
+		require.Equal(t, []reflect.Type{reflect.TypeOf(val), reflect.TypeOf(Celsius(0))}, hooked)
+	})
+
+	t.Run("HookError", func(t *testing.T) {
+		called := false
+		err := New(func(info *WalkInfo) error {
+			called = true
+			return nil
+		}).WithNamedTypeHook(func(info *WalkInfo) error {
+			return errTest
+		}).Walk(val)
+		require.ErrorIs(t, err, errTest)
+		require.False(t, called)
+	})
+}
+
+func TestWalkInfo_ChildTypes(t *testing.T) {
+	type S struct {
+		A int
+		B string
+		C []byte
+	}
+
+	for _, test := range []struct {
+		name string
+		val  interface{}
+		res  []reflect.Type
+	}{
+		{"Struct", S{}, []reflect.Type{reflect.TypeOf(0), reflect.TypeOf(""), reflect.TypeOf([]byte(nil))}},
+		{"Map", map[string]float64{}, []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(float64(0))}},
+		{"Slice", []int8{}, []reflect.Type{reflect.TypeOf(int8(0))}},
+		{"Array", [2]uint{}, []reflect.Type{reflect.TypeOf(uint(0))}},
+		{"Ptr", &S{}, []reflect.Type{reflect.TypeOf(S{})}},
+		{"Interface", []interface{}{1}, []reflect.Type{reflect.TypeOf((*interface{})(nil)).Elem()}},
+		{"Int", 1, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var res []reflect.Type
+			callbackCalled := false
+			err := New(func(info *WalkInfo) error {
+				if !callbackCalled {
+					res = info.ChildTypes()
+				}
+				callbackCalled = true
+				return ErrSkip
+			}).Walk(test.val)
+			require.NoError(t, err)
+			require.True(t, callbackCalled)
+			require.Equal(t, test.res, res)
+		})
+	}
+
+	t.Run("InterfaceItems", func(t *testing.T) {
+		var res [][]reflect.Type
+		err := New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Interface {
+				res = append(res, info.ChildTypes())
+			}
+			return nil
+		}).Walk([]interface{}{"str", nil})
+		require.NoError(t, err)
+		require.Equal(t, [][]reflect.Type{{reflect.TypeOf("")}, nil}, res)
+	})
+}
+
+func TestWalkInfo_Container(t *testing.T) {
+	type S struct {
+		Slice []int
+		Map   map[string]int
+		Ptr   *int
+	}
+	val := S{Slice: []int{1}, Map: map[string]int{"k": 2}, Ptr: new(int)}
+
+	containers := map[string][]interface{}{}
+	err := New(func(info *WalkInfo) error {
+		container, ok := info.Container()
+		var res interface{}
+		if ok {
+			res = container.Interface()
+		}
+		containers[info.Path()] = append(containers[info.Path()], res)
+		return nil
+	}).Walk(val)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string][]interface{}{
+		"":          {nil},
+		".Slice":    {val},
+		".Slice[0]": {val.Slice},
+		".Map":      {val},
+		".Map{k}":   {val.Map},
+		".Map[k]":   {val.Map},
+		".Ptr":      {val, nil},
+	}, containers)
+}
+
+func TestWalkInfo_Tag(t *testing.T) {
+	type S struct {
+		A int `json:"x,omitempty" xml:""`
+		B int
+	}
+
+	type tagResult struct {
+		val string
+		ok  bool
+	}
+	tags := map[string][]tagResult{}
+	err := New(func(info *WalkInfo) error {
+		for _, key := range []string{"json", "xml"} {
+			val, ok := info.Tag(key)
+			tags[info.Path()] = append(tags[info.Path()], tagResult{val: val, ok: ok})
+		}
+		return nil
+	}).Walk(S{})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string][]tagResult{
+		"":   {{}, {}},
+		".A": {{val: "x,omitempty", ok: true}, {val: "", ok: true}},
+		".B": {{}, {}},
+	}, tags)
+}
+
+func TestWalkInfo_IsPointerTarget(t *testing.T) {
+	type S struct {
+		Ptr   *int
+		Int   int
+		Iface interface{}
+	}
+	x := 1
+	targets := map[string][]bool{}
+	err := New(func(info *WalkInfo) error {
+		key := info.Path() + ":" + info.Value.Kind().String()
+		targets[key] = append(targets[key], info.IsPointerTarget())
+		return nil
+	}).Walk(&S{Ptr: &x, Iface: 2})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]bool{
+		":ptr":             {false},
+		":struct":          {true},
+		".Ptr:ptr":         {false},
+		".Ptr:int":         {true},
+		".Int:int":         {false},
+		".Iface:interface": {false},
+		".Iface:int":       {false},
+	}, targets)
+}
+
+func TestWalkInfo_InterfaceStaticType(t *testing.T) {
+	type S struct {
+		Reader io.Reader
+		Any    interface{}
+		Ptr    *int
+	}
+	x := 1
+	staticTypes := map[string]reflect.Type{}
+	err := New(func(info *WalkInfo) error {
+		staticTypes[info.Path()+":"+info.Value.Kind().String()] = info.InterfaceStaticType()
+		return nil
+	}).Walk(S{Reader: testReaderFunc(nil), Any: 2, Ptr: &x})
+	require.NoError(t, err)
+	require.Equal(t, map[string]reflect.Type{
+		":struct":           nil,
+		".Reader:interface": nil,
+		".Reader:func":      reflect.TypeOf((*io.Reader)(nil)).Elem(),
+		".Any:interface":    nil,
+		".Any:int":          reflect.TypeOf((*interface{})(nil)).Elem(),
+		".Ptr:ptr":          nil,
+		".Ptr:int":          nil,
+	}, staticTypes)
+}
+
+func TestWalkInfo_IsTypedNil(t *testing.T) {
+	x := 1
+	typedNil := map[string][]bool{}
+	err := New(func(info *WalkInfo) error {
+		key := info.Path() + ":" + info.Value.Kind().String()
+		typedNil[key] = append(typedNil[key], info.IsTypedNil())
+		return nil
+	}).Walk([]interface{}{nil, (*int)(nil), &x, 2})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]bool{
+		":slice":        {false},
+		"[0]:interface": {false},
+		"[1]:interface": {true},
+		"[1]:ptr":       {true},
+		"[2]:interface": {false},
+		"[2]:ptr":       {false},
+		"[2]:int":       {false},
+		"[3]:interface": {false},
+		"[3]:int":       {false},
+	}, typedNil)
+}
+
+func TestWalkInfo_FieldIndexPath(t *testing.T) {
+	type Inner struct {
+		A int
+		B string
+	}
+	type Middle struct {
+		X     bool
+		Inner Inner
+	}
+	type Outer struct {
+		Name   string
+		Middle Middle
+		Items  []Inner
+	}
+
+	val := Outer{
+		Name:   "name",
+		Middle: Middle{X: true, Inner: Inner{A: 1, B: "b"}},
+		Items:  []Inner{{A: 2, B: "c"}},
+	}
+
+	paths := map[string][]int{}
+	err := New(func(info *WalkInfo) error {
+		indexPath := info.FieldIndexPath()
+		paths[info.Path()] = indexPath
+		if indexPath == nil {
+			return nil
+		}
+
+		ancestor := info
+		for range indexPath {
+			ancestor = ancestor.Parent
+		}
+		require.Equal(t, info.Value.Interface(), ancestor.Value.FieldByIndex(indexPath).Interface(), info.Path())
+		return nil
+	}).Walk(val)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int{
+		"":                nil,
+		".Name":           {0},
+		".Middle":         {1},
+		".Middle.X":       {1, 0},
+		".Middle.Inner":   {1, 1},
+		".Middle.Inner.A": {1, 1, 0},
+		".Middle.Inner.B": {1, 1, 1},
+		".Items":          {2},
+		".Items[0]":       nil,
+		".Items[0].A":     {0},
+		".Items[0].B":     {1},
+	}, paths)
+
+	t.Run("TransparentPointers", func(t *testing.T) {
+		type P struct {
+			Inner *Inner
+		}
+		indexPaths := walkCollect(t, New(nil).WithTransparentPointers(true), P{Inner: &Inner{}}, func(info *WalkInfo) (string, bool) {
+			return fmt.Sprintf("%v:%v", info.Path(), info.FieldIndexPath()), true
+		})
+		require.Equal(t, []string{":[]", ".Inner:[]", ".Inner.A:[0]", ".Inner.B:[1]"}, indexPaths)
+	})
+}
+
+func TestWalker_Siblings(t *testing.T) {
+	type S struct {
+		A int
+		B []string
+		C int
+	}
+
+	type position struct {
+		index, count int
+	}
+	positions := map[string]position{}
+	require.NoError(t, New(func(info *WalkInfo) error {
+		positions[info.Path()] = position{info.SiblingIndex, info.SiblingCount}
+		return nil
+	}).Walk(S{B: []string{"1", "2"}}))
+	require.Equal(t, map[string]position{
+		"":      {-1, -1},
+		".A":    {0, 3},
+		".B":    {1, 3},
+		".B[0]": {0, 2},
+		".B[1]": {1, 2},
+		".C":    {2, 3},
+	}, positions)
+}
+
+func TestWalkInfo_SkipChildren(t *testing.T) {
+	type Inner struct {
+		Val int
+	}
+	type S struct {
+		Inner Inner
+		Map   map[int]int
+		After int
+	}
+
+	var visited []string
+	require.NoError(t, New(func(info *WalkInfo) error {
+		visited = append(visited, info.Path())
+		if (info.Value.Kind() == reflect.Struct && info.Parent != nil) || info.IsMapKey() {
+			info.SkipChildren()
+		}
+		return nil
+	}).Walk(S{Map: map[int]int{1: 2}}))
+	require.Equal(t, []string{"", ".Inner", ".Map", ".Map{1}", ".Map[1]", ".After"}, visited)
+}
+
+func TestWalkInfo_Root(t *testing.T) {
+	type Inner struct {
+		Val int
+	}
+	type S struct {
+		Items []Inner
+	}
+
+	var root, leaf *WalkInfo
+	require.NoError(t, New(func(info *WalkInfo) error {
+		if info.Parent == nil {
+			root = info
+			require.Same(t, info, info.Root())
+		}
+		if info.Value.Kind() == reflect.Int {
+			leaf = info
+		}
+		return nil
+	}).Walk(&S{Items: []Inner{{Val: 1}}}))
+	require.NotNil(t, leaf)
+	require.Same(t, root, leaf.Root())
+	require.Same(t, root, leaf.Root())
+	require.Same(t, root, leaf.Parent.Root())
+}
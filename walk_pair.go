@@ -0,0 +1,204 @@
+package objwalker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// WalkPairFunc is type of callback for WalkPair
+type WalkPairFunc func(ai, bi *WalkInfo) error
+
+type (
+	pairFieldKey    int
+	pairIndexKey    int
+	pairElemKey     struct{}
+	pairMapKeyKey   struct{ key interface{} }
+	pairMapValueKey struct{ key interface{} }
+	pairUniqueKey   struct{ _ byte }
+
+	// pairNaNKey is match key of map keys, which contains NaN and doesn't equal to itself:
+	// n-th key with the text in one map matched with n-th key with same text in other map
+	pairNaNKey struct {
+		text string
+		n    int
+	}
+)
+
+type pairChild struct {
+	key  interface{}
+	info *WalkInfo
+}
+
+type pairWalker struct {
+	a *walkerState
+	b *walkerState
+	f WalkPairFunc
+}
+
+// WalkPair walk over a and b in parallel and call f with corresponding values of them:
+// same struct fields, items with same indexes, map entries with same keys, pointed values.
+// If structures has different shape - one of f arguments is nil: for example for items of longer slice,
+// for map entries without pair or for children of values with different types.
+// Map keys with NaN matched in order of map iteration.
+// If f return ErrSkip - skip children of both values, ErrStop stop walk without error,
+// other errors stop walk and returned from WalkPair.
+// Nil and invalid reflect.Value roots handled as absent, same as in Walker.Walk.
+// Loop protection work for every object separately, visited value handled as absent.
+func WalkPair(a, b interface{}, f WalkPairFunc) error {
+	pw := pairWalker{
+		a: newWalkerState(*New(nil)),
+		b: newWalkerState(*New(nil)),
+		f: f,
+	}
+	ai, err := pw.a.pairRootInfo(a)
+	if err != nil {
+		return err
+	}
+	bi, err := pw.b.pairRootInfo(b)
+	if err != nil {
+		return err
+	}
+	err = pw.walk(ai, bi)
+	if errors.Is(err, ErrStop) {
+		return nil
+	}
+	return err
+}
+
+func (state *walkerState) pairRootInfo(v interface{}) (*WalkInfo, error) {
+	rv, ok, err := state.rootValue(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return state.newWalkerInfo(rv, nil), nil
+}
+
+func (pw *pairWalker) walk(ai, bi *WalkInfo) error {
+	if ai != nil {
+		pw.a.loopDetector(ai)
+		if ai.IsVisited {
+			ai = nil
+		}
+	}
+	if bi != nil {
+		pw.b.loopDetector(bi)
+		if bi.IsVisited {
+			bi = nil
+		}
+	}
+	if ai == nil && bi == nil {
+		return nil
+	}
+
+	if err := pw.f(ai, bi); err != nil {
+		if errors.Is(err, ErrSkip) {
+			return nil
+		}
+		return err
+	}
+
+	aChildren := pw.a.pairChildren(ai)
+	bChildren := pw.b.pairChildren(bi)
+
+	if ai == nil || bi == nil || ai.Value.Type() != bi.Value.Type() {
+		for _, child := range aChildren {
+			if err := pw.walk(child.info, nil); err != nil {
+				return err
+			}
+		}
+		for _, child := range bChildren {
+			if err := pw.walk(nil, child.info); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	bByKey := make(map[interface{}]*WalkInfo, len(bChildren))
+	for _, child := range bChildren {
+		bByKey[child.key] = child.info
+	}
+	for _, child := range aChildren {
+		bChild := bByKey[child.key]
+		delete(bByKey, child.key)
+		if err := pw.walk(child.info, bChild); err != nil {
+			return err
+		}
+	}
+	for _, child := range bChildren {
+		if _, ok := bByKey[child.key]; !ok {
+			continue
+		}
+		if err := pw.walk(nil, child.info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pairChildren return children of info with keys for match with children of other value
+func (state *walkerState) pairChildren(info *WalkInfo) []pairChild {
+	if info == nil {
+		return nil
+	}
+
+	v := info.Value
+	var res []pairChild
+
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			res = append(res, pairChild{key: pairElemKey{}, info: state.newWalkerInfo(v.Elem(), info)})
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			fieldInfo := state.newWalkerInfo(v.Field(i), info)
+			fieldInfo.StructField = &field
+			fieldInfo.SiblingIndex = i
+			fieldInfo.SiblingCount = v.NumField()
+			res = append(res, pairChild{key: pairFieldKey(i), info: fieldInfo})
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			itemInfo := state.newWalkerInfo(v.Index(i), info)
+			itemInfo.Index = i
+			itemInfo.SiblingIndex = i
+			itemInfo.SiblingCount = v.Len()
+			res = append(res, pairChild{key: pairIndexKey(i), info: itemInfo})
+		}
+	case reflect.Map:
+		var nanKeys map[string]int
+		iterator := v.MapRange()
+		for iterator.Next() {
+			key := iterator.Key()
+
+			// keys, which can't be compared, has no pair
+			var matchKey interface{} = &pairUniqueKey{}
+			if key.CanInterface() {
+				matchKey = key.Interface()
+			}
+			if !key.Equal(key) {
+				if nanKeys == nil {
+					nanKeys = make(map[string]int)
+				}
+				text := fmt.Sprint(key)
+				matchKey = pairNaNKey{text: text, n: nanKeys[text]}
+				nanKeys[text]++
+			}
+
+			keyInfo := state.newWalkerInfo(key, info)
+			keyInfo.isMapKey = true
+			keyInfo.mapKey = key
+			res = append(res, pairChild{key: pairMapKeyKey{key: matchKey}, info: keyInfo})
+
+			valInfo := state.newWalkerInfo(iterator.Value(), info)
+			valInfo.isMapValue = true
+			valInfo.mapKey = key
+			res = append(res, pairChild{key: pairMapValueKey{key: matchKey}, info: valInfo})
+		}
+	}
+	return res
+}
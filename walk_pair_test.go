@@ -0,0 +1,155 @@
+package objwalker
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkPair(t *testing.T) {
+	type S struct {
+		Name  string
+		Items []int
+		M     map[string]int
+	}
+
+	pathPair := func(ai, bi *WalkInfo) string {
+		res := "<nil>"
+		if ai != nil {
+			res = ai.Path()
+		}
+		res += "|"
+		if bi != nil {
+			res += bi.Path()
+		} else {
+			res += "<nil>"
+		}
+		return res
+	}
+
+	t.Run("Aligned", func(t *testing.T) {
+		a := S{Name: "a", Items: []int{1, 2}, M: map[string]int{"x": 1, "y": 2}}
+		b := S{Name: "b", Items: []int{3}, M: map[string]int{"x": 3, "z": 4}}
+
+		var pairs []string
+		values := map[string][2]interface{}{}
+		err := WalkPair(a, b, func(ai, bi *WalkInfo) error {
+			p := pathPair(ai, bi)
+			pairs = append(pairs, p)
+			var vals [2]interface{}
+			if ai != nil {
+				vals[0] = ai.Value.Interface()
+			}
+			if bi != nil {
+				vals[1] = bi.Value.Interface()
+			}
+			values[p] = vals
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"|", ".Name|.Name", ".Items|.Items", ".Items[0]|.Items[0]", ".Items[1]|<nil>", ".M|.M"}, pairs[:6])
+		require.ElementsMatch(t, []string{
+			".M{x}|.M{x}", ".M[x]|.M[x]",
+			".M{y}|<nil>", ".M[y]|<nil>",
+			"<nil>|.M{z}", "<nil>|.M[z]",
+		}, pairs[6:])
+
+		require.Equal(t, [2]interface{}{"a", "b"}, values[".Name|.Name"])
+		require.Equal(t, [2]interface{}{1, 3}, values[".Items[0]|.Items[0]"])
+		require.Equal(t, [2]interface{}{2, nil}, values[".Items[1]|<nil>"])
+		require.Equal(t, [2]interface{}{1, 3}, values[".M[x]|.M[x]"])
+		require.Equal(t, [2]interface{}{nil, 4}, values["<nil>|.M[z]"])
+	})
+
+	t.Run("DifferentTypes", func(t *testing.T) {
+		var pairs []string
+		err := WalkPair([]int{1}, struct{ A int }{A: 2}, func(ai, bi *WalkInfo) error {
+			pairs = append(pairs, pathPair(ai, bi))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"|", "[0]|<nil>", "<nil>|.A"}, pairs)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var pairs []string
+		err := WalkPair(nil, []int{1}, func(ai, bi *WalkInfo) error {
+			pairs = append(pairs, pathPair(ai, bi))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"<nil>|", "<nil>|[0]"}, pairs)
+
+		pairs = nil
+		err = WalkPair(reflect.Value{}, []int{1}, func(ai, bi *WalkInfo) error {
+			pairs = append(pairs, pathPair(ai, bi))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"<nil>|", "<nil>|[0]"}, pairs)
+	})
+
+	t.Run("NaNKeys", func(t *testing.T) {
+		a := map[float64]int{math.NaN(): 1, 1: 2}
+		b := map[float64]int{math.NaN(): 3, 1: 4}
+		var pairs []string
+		err := WalkPair(a, b, func(ai, bi *WalkInfo) error {
+			pairs = append(pairs, pathPair(ai, bi))
+			return nil
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"|", "{NaN}|{NaN}", "[NaN]|[NaN]", "{1}|{1}", "[1]|[1]"}, pairs)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		var pairs []string
+		err := WalkPair([]int{1, 2}, []int{1, 2}, func(ai, bi *WalkInfo) error {
+			pairs = append(pairs, pathPair(ai, bi))
+			if ai.Index == 0 {
+				return ErrStop
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"|", "[0]|[0]"}, pairs)
+	})
+
+	t.Run("SkipAndError", func(t *testing.T) {
+		a := S{Items: []int{1}}
+		var pairs []string
+		err := WalkPair(a, a, func(ai, bi *WalkInfo) error {
+			pairs = append(pairs, pathPair(ai, bi))
+			if ai.StructField != nil && ai.StructField.Name == "Items" {
+				return ErrSkip
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"|", ".Name|.Name", ".Items|.Items", ".M|.M"}, pairs)
+
+		err = WalkPair(a, a, func(ai, bi *WalkInfo) error {
+			return errTest
+		})
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		type L struct {
+			Next *L
+		}
+		a := &L{}
+		a.Next = a
+		b := &L{Next: &L{}}
+
+		var pairs []string
+		err := WalkPair(a, b, func(ai, bi *WalkInfo) error {
+			pairs = append(pairs, pathPair(ai, bi))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"|", "|", ".Next|.Next", "<nil>|.Next", "<nil>|.Next.Next"}, pairs)
+	})
+}
@@ -0,0 +1,107 @@
+package objwalker
+
+import (
+	"errors"
+	"reflect"
+)
+
+// WalkTypeFunc is type of callback for WalkType, path has format of WalkInfo.Path
+// with "[]" for slice and array items, "{}" for map keys and "[]" for map values
+type WalkTypeFunc func(t reflect.Type, path string) error
+
+// WalkType walk over structure of type without value: struct fields, items of slices and arrays,
+// keys and values of maps and elements of pointers and chans.
+// Named types doesn't walked again inside of themselves, it protect from infinite walk over recursive types,
+// but the types sent to callback.
+// If f return ErrSkip - skip children of the type, ErrStop - stop walk and WalkType return nil,
+// other errors stop walk and returned from WalkType.
+func WalkType(t reflect.Type, f WalkTypeFunc) error {
+	if t == nil {
+		return nil
+	}
+	err := walkType(t, "", f, make(map[reflect.Type]empty))
+	if errors.Is(err, ErrStop) {
+		return nil
+	}
+	return err
+}
+
+func walkType(t reflect.Type, path string, f WalkTypeFunc, parents map[reflect.Type]empty) error {
+	if err := f(t, path); err != nil {
+		if errors.Is(err, ErrSkip) {
+			return nil
+		}
+		return err
+	}
+
+	if t.Name() != "" {
+		if _, ok := parents[t]; ok {
+			return nil
+		}
+		parents[t] = empty{}
+		defer delete(parents, t)
+	}
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if err := walkType(field.Type, path+"."+field.Name, f, parents); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		return walkType(t.Elem(), path+"[]", f, parents)
+	case reflect.Map:
+		if err := walkType(t.Key(), path+"{}", f, parents); err != nil {
+			return err
+		}
+		return walkType(t.Elem(), path+"[]", f, parents)
+	case reflect.Pointer, reflect.Chan:
+		return walkType(t.Elem(), path, f, parents)
+	}
+	return nil
+}
+
+// IsRecursiveType return true if type or any type, reachable from it by struct fields or element, key and value
+// types, can contain itself: for example struct with pointer to same struct. Values of such types can has cycles.
+// Dynamic types of interfaces doesn't analyzed.
+func IsRecursiveType(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	return hasTypeCycle(t, make(map[reflect.Type]bool))
+}
+
+// hasTypeCycle check type graph for cycles by depth first search,
+// inProgress contains true for types in current path and false for checked types without cycles
+func hasTypeCycle(t reflect.Type, inProgress map[reflect.Type]bool) bool {
+	if current, checked := inProgress[t]; checked {
+		return current
+	}
+	inProgress[t] = true
+
+	var children []reflect.Type
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			children = append(children, t.Field(i).Type)
+		}
+	case reflect.Slice, reflect.Array, reflect.Pointer, reflect.Chan:
+		children = append(children, t.Elem())
+	case reflect.Map:
+		children = append(children, t.Key(), t.Elem())
+	}
+
+	for _, child := range children {
+		if hasTypeCycle(child, inProgress) {
+			return true
+		}
+	}
+
+	inProgress[t] = false
+	return false
+}
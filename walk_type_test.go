@@ -0,0 +1,123 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type walkTypeNode struct {
+	Name     string
+	Next     *walkTypeNode
+	Children []walkTypeNode
+	Attrs    map[string]int
+}
+
+func TestWalkType(t *testing.T) {
+	type pathType struct {
+		path string
+		t    reflect.Type
+	}
+
+	walk := func(t reflect.Type, skipPath string) ([]pathType, error) {
+		var res []pathType
+		err := WalkType(t, func(t reflect.Type, path string) error {
+			res = append(res, pathType{path: path, t: t})
+			if path == skipPath {
+				return ErrSkip
+			}
+			return nil
+		})
+		return res, err
+	}
+
+	nodeType := reflect.TypeOf(walkTypeNode{})
+
+	t.Run("Recursive", func(t *testing.T) {
+		res, err := walk(nodeType, "-")
+		require.NoError(t, err)
+		require.Equal(t, []pathType{
+			{"", nodeType},
+			{".Name", reflect.TypeOf("")},
+			{".Next", reflect.PtrTo(nodeType)},
+			{".Next", nodeType},
+			{".Children", reflect.TypeOf([]walkTypeNode{})},
+			{".Children[]", nodeType},
+			{".Attrs", reflect.TypeOf(map[string]int{})},
+			{".Attrs{}", reflect.TypeOf("")},
+			{".Attrs[]", reflect.TypeOf(0)},
+		}, res)
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		res, err := walk(reflect.TypeOf(struct {
+			A map[int]string
+			B []int
+		}{}), ".A")
+		require.NoError(t, err)
+		require.Len(t, res, 4)
+		require.Equal(t, ".B[]", res[3].path)
+	})
+
+	t.Run("StopAndError", func(t *testing.T) {
+		calls := 0
+		err := WalkType(nodeType, func(t reflect.Type, path string) error {
+			calls++
+			return ErrStop
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, calls)
+
+		err = WalkType(nodeType, func(t reflect.Type, path string) error {
+			if path == ".Attrs" {
+				return errTest
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		require.NoError(t, WalkType(nil, func(t reflect.Type, path string) error {
+			return errTest
+		}))
+	})
+}
+
+func TestIsRecursiveType(t *testing.T) {
+	type List struct {
+		Val  int
+		Next *List
+	}
+	type Flat struct {
+		A int
+		B []string
+		C map[string]*int
+	}
+	type HasList struct {
+		Name string
+		List List
+	}
+	type MapTree map[string]MapTree
+
+	for _, test := range []struct {
+		name string
+		t    reflect.Type
+		res  bool
+	}{
+		{"List", reflect.TypeOf(List{}), true},
+		{"ListPtr", reflect.TypeOf(&List{}), true},
+		{"Node", reflect.TypeOf(walkTypeNode{}), true},
+		{"HasList", reflect.TypeOf(HasList{}), true},
+		{"MapTree", reflect.TypeOf(MapTree{}), true},
+		{"Flat", reflect.TypeOf(Flat{}), false},
+		{"Int", reflect.TypeOf(0), false},
+		{"Interface", reflect.TypeOf([]interface{}{}), false},
+		{"Nil", nil, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, IsRecursiveType(test.t))
+		})
+	}
+}
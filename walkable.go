@@ -0,0 +1,65 @@
+package objwalker
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Walkable can be implemented by types, which know own logical children better then reflection.
+// Walker use WalkChildren result instead of reflection walk over value if Walker.RespectWalkable is true.
+type Walkable interface {
+	WalkChildren() []interface{}
+}
+
+var walkableType = reflect.TypeOf((*Walkable)(nil)).Elem()
+
+// asWalkable return Walkable implementation of value, if it exists.
+// It use pointer to value if Walkable implemented by pointer receiver and value addressable.
+// Pointers and interfaces handled by they elements, it allow loop protection detect cycles through WalkChildren.
+func asWalkable(v reflect.Value) (Walkable, bool) {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return nil, false
+	case reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return nil, false
+		}
+	}
+
+	if v.Type().Implements(walkableType) && v.CanInterface() {
+		return v.Interface().(Walkable), true
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(walkableType) && v.Addr().CanInterface() {
+		return v.Addr().Interface().(Walkable), true
+	}
+	return nil, false
+}
+
+func (state *walkerState) walkWalkable(info *WalkInfo, walkable Walkable) error {
+	return state.walkLogicalChildren(info, walkable.WalkChildren)
+}
+
+// walkLogicalChildren call callback for info, then walk over values, returned by getChildren, as children of info
+func (state *walkerState) walkLogicalChildren(info *WalkInfo, getChildren func() []interface{}) error {
+	if err := state.call(info); err != nil {
+		if errors.Is(err, ErrSkip) {
+			return nil
+		}
+		return err
+	}
+
+	children := getChildren()
+	for i, child := range children {
+		if child == nil {
+			continue
+		}
+		childInfo := state.newWalkerInfo(reflect.ValueOf(child), info)
+		childInfo.SiblingIndex = i
+		childInfo.SiblingCount = len(children)
+		if err := state.walkValue(childInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package objwalker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type walkableTree struct {
+	Name     string
+	children []*walkableTree
+	parent   *walkableTree
+}
+
+func (t *walkableTree) WalkChildren() []interface{} {
+	res := []interface{}{t.Name}
+	for _, child := range t.children {
+		res = append(res, child)
+	}
+	return res
+}
+
+type selfWalkable struct {
+	Name string
+}
+
+func (s *selfWalkable) WalkChildren() []interface{} {
+	return []interface{}{s.Name, s}
+}
+
+func TestWalker_RespectWalkable(t *testing.T) {
+	root := &walkableTree{Name: "root"}
+	child := &walkableTree{Name: "child", parent: root}
+	root.children = []*walkableTree{child}
+
+	t.Run("Walkable", func(t *testing.T) {
+		var names []string
+		var kinds []reflect.Kind
+		require.NoError(t, New(func(info *WalkInfo) error {
+			kinds = append(kinds, info.Value.Kind())
+			if info.Value.Kind() == reflect.String {
+				names = append(names, info.Value.String())
+			}
+			return nil
+		}).WithRespectWalkable(true).Walk(root))
+		require.Equal(t, []string{"root", "child"}, names)
+		require.Equal(t, []reflect.Kind{
+			reflect.Pointer, reflect.Struct, reflect.String,
+			reflect.Pointer, reflect.Struct, reflect.String,
+		}, kinds)
+	})
+
+	t.Run("AddressableValue", func(t *testing.T) {
+		var names []string
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.String {
+				names = append(names, info.Value.String())
+			}
+			return nil
+		}).WithRespectWalkable(true).Walk(&struct{ Tree walkableTree }{Tree: *root}))
+		require.Equal(t, []string{"root", "child"}, names)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		wasStruct := false
+		require.NoError(t, New(func(info *WalkInfo) error {
+			if info.Value.Kind() == reflect.Struct {
+				wasStruct = true
+			}
+			return nil
+		}).Walk(root))
+		require.True(t, wasStruct)
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		calls := 0
+		require.NoError(t, New(func(info *WalkInfo) error {
+			calls++
+			return ErrSkip
+		}).WithRespectWalkable(true).Walk(root))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("SelfReturning", func(t *testing.T) {
+		self := &selfWalkable{Name: "self"}
+		require.Equal(t, []string{":ptr", ":struct", ":string", ":ptr"},
+			walkKindPaths(t, New(nil).WithRespectWalkable(true), self))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var tree *walkableTree
+		require.NoError(t, New(func(info *WalkInfo) error {
+			return nil
+		}).WithRespectWalkable(true).Walk(tree))
+	})
+}